@@ -5,6 +5,7 @@ package dateparse
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -46,17 +47,20 @@ const (
 	dateDigitDash
 	dateDigitDashAlpha
 	dateDigitDashAlphaDash
-	dateDigitDot // 10
+	dateDigitDashDigit // 10
+	dateDigitDot
 	dateDigitDotDot
 	dateDigitSlash
 	dateDigitChineseYear
-	dateDigitChineseYearWs
-	dateDigitWs // 15
+	dateDigitChineseYearWs // 15
+	dateDigitWs
 	dateDigitWsMoYear
 	dateDigitWsMolong
+	dateDigitAlpha
+	dateDigitAlphaDigit // 20
 	dateAlpha
 	dateAlphaWs
-	dateAlphaWsDigit // 20
+	dateAlphaWsDigit
 	dateAlphaWsDigitMore
 	dateAlphaWsDigitMoreWs
 	dateAlphaWsDigitMoreWsYear
@@ -105,1610 +109,5070 @@ const (
 	timePeriodWsOffsetColonAlpha
 	timeZ
 	timeZDigit
+	timeWsYearAlpha
 )
 
 var (
 	// ErrAmbiguousMMDD for date formats such as 04/02/2014 the mm/dd vs dd/mm are
 	// ambiguous, so it is an error for strict parse rules.
 	ErrAmbiguousMMDD = fmt.Errorf("This date has ambiguous mm/dd vs dd/mm type format")
+	// ErrNotBusinessDay is returned by the WithBusinessDaysOnly option for
+	// dates that fall on a weekend or holiday.
+	ErrNotBusinessDay = fmt.Errorf("This date is not a business day")
 )
 
-func unknownErr(datestr string) error {
-	return fmt.Errorf("Could not find format for %q", datestr)
+// zoneProbe is an unlikely location, a date-string parsed in it giving
+// the same time as in UTC has its own zone.
+var zoneProbe = time.FixedZone("", 13*60*60+17*60)
+
+// nowFunc is the current time for relative and partial date-strings
+// parsed without the WithBaseDate option.
+var nowFunc = time.Now
+
+// registeredLayouts are the layouts of RegisterLayout, in the order they
+// are tried.
+var registeredLayouts []string
+
+// RegisterLayout adds a time.Parse layout that is tried, before the built
+// in formats, by ParseAny and the other parse functions.  The first of the
+// registered layouts to parse a date-string is its layout.  It is not safe
+// to call concurrently with parsing, register layouts at init.
+//
+//     dateparse.RegisterLayout("2006.01.02 AD at 15:04:05")
+//
+func RegisterLayout(layout string) {
+	registeredLayouts = append(registeredLayouts, layout)
 }
 
-// ParseAny parse an unknown date format, detect the layout.
-// Normal parse.  Equivalent Timezone rules as time.Parse().
-// NOTE:  please see readme on mmdd vs ddmm ambiguous dates.
-func ParseAny(datestr string) (time.Time, error) {
-	p, err := parseTime(datestr, nil)
-	if err != nil {
-		return time.Time{}, err
+// SetNowFunc sets the function returning the current time that relative
+// and partial date-strings are resolved from, so tests can freeze time.
+// A WithBaseDate date or WithClock clock takes precedence over it, and it
+// over time.Now, the default restored by a nil fn.  It is not safe to
+// call concurrently with parsing, prefer WithClock.
+func SetNowFunc(fn func() time.Time) {
+	if fn == nil {
+		fn = time.Now
 	}
-	return p.parse()
+	nowFunc = fn
 }
 
-// ParseIn with Location, equivalent to time.ParseInLocation() timezone/offset
-// rules.  Using location arg, if timezone/offset info exists in the
-// datestring, it uses the given location rules for any zone interpretation.
-// That is, MST means one thing when using America/Denver and something else
-// in other locations.
-func ParseIn(datestr string, loc *time.Location) (time.Time, error) {
-	p, err := parseTime(datestr, loc)
-	if err != nil {
-		return time.Time{}, err
-	}
-	return p.parse()
+// Clock is the source of the current time for relative and partial
+// date-strings, see WithClock.
+type Clock interface {
+	Now() time.Time
 }
 
-// ParseLocal Given an unknown date format, detect the layout,
-// using time.Local, parse.
+// FixedClock is a Clock that is always at the same time, for tests and
+// replays.
 //
-// Set Location to time.Local.  Same as ParseIn Location but lazily uses
-// the global time.Local variable for Location argument.
+//     dateparse.ParseAny("yesterday", dateparse.EnableRelative(true),
+//         dateparse.WithClock(dateparse.FixedClock(t)))
 //
-//     denverLoc, _ := time.LoadLocation("America/Denver")
-//     time.Local = denverLoc
+type FixedClock time.Time
+
+// Now is the fixed time.
+func (c FixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// zoneOffsets are the offsets, in seconds east of UTC, of well known
+// zone abbreviations.
+var zoneOffsets = map[string]int{
+	"UTC":  0,
+	"GMT":  0,
+	"WET":  0,
+	"WEST": 1 * 3600,
+	"BST":  1 * 3600,
+	"CET":  1 * 3600,
+	"CEST": 2 * 3600,
+	"EET":  2 * 3600,
+	"EEST": 3 * 3600,
+	"MSK":  3 * 3600,
+	"IST":  5*3600 + 1800,
+	"CST":  -6 * 3600,
+	"CDT":  -5 * 3600,
+	"EST":  -5 * 3600,
+	"EDT":  -4 * 3600,
+	"MST":  -7 * 3600,
+	"MDT":  -6 * 3600,
+	"PST":  -8 * 3600,
+	"PDT":  -7 * 3600,
+	"AKST": -9 * 3600,
+	"AKDT": -8 * 3600,
+	"HST":  -10 * 3600,
+	"JST":  9 * 3600,
+	"KST":  9 * 3600,
+	"AEST": 10 * 3600,
+	"AEDT": 11 * 3600,
+	"NZST": 12 * 3600,
+	"NZDT": 13 * 3600,
+}
+
+// locationZones are the north american zone abbreviations of zoneOffsets
+// that are read in the location instead, as time.Parse does, so PST in
+// July is PDT in America/Los_Angeles and UTC without a location.
+var locationZones = map[string]bool{
+	"CST": true, "CDT": true, "EST": true, "EDT": true, "MST": true, "MDT": true,
+	"PST": true, "PDT": true, "AKST": true, "AKDT": true, "HST": true,
+}
+
+// language is the localized notation of a WithLanguage language.
+type language struct {
+	// names maps lower case month and weekday names to english
+	names map[string]string
+	// rewrite is applied after the names are translated
+	rewrite func(datestr string) string
+	// dayFirst reads ambiguous numeric dates day first, 02/01/2014 is
+	// 2 January, unless PreferDayFirst says otherwise
+	dayFirst bool
+	// weekday reads the weekday of a (土) annotation
+	weekday func(name string) (time.Weekday, bool)
+}
+
+var languages = map[string]language{
+	"en": {},
+	"fr": {names: frenchNames, rewrite: frenchTime, dayFirst: true},
+	"de": {names: germanNames, rewrite: germanDay},
+	"ja": {weekday: japaneseWeekday},
+	"zh": {weekday: chineseWeekday},
+}
+
+var japaneseWeekdays = map[string]time.Weekday{
+	"日": time.Sunday,
+	"月": time.Monday,
+	"火": time.Tuesday,
+	"水": time.Wednesday,
+	"木": time.Thursday,
+	"金": time.Friday,
+	"土": time.Saturday,
+}
+
+// japaneseWeekday reads 土, 土曜 or 土曜日.
+func japaneseWeekday(name string) (time.Weekday, bool) {
+	if strings.HasSuffix(name, "曜日") {
+		name = strings.TrimSuffix(name, "曜日")
+	} else {
+		name = strings.TrimSuffix(name, "曜")
+	}
+	day, ok := japaneseWeekdays[name]
+	return day, ok
+}
+
+var chineseWeekdays = map[string]time.Weekday{
+	"日": time.Sunday,
+	"天": time.Sunday,
+	"一": time.Monday,
+	"二": time.Tuesday,
+	"三": time.Wednesday,
+	"四": time.Thursday,
+	"五": time.Friday,
+	"六": time.Saturday,
+}
+
+// chineseWeekday reads 六, 周六 or 星期六.
+func chineseWeekday(name string) (time.Weekday, bool) {
+	if strings.HasPrefix(name, "星期") {
+		name = strings.TrimPrefix(name, "星期")
+	} else {
+		name = strings.TrimPrefix(name, "周")
+	}
+	day, ok := chineseWeekdays[name]
+	return day, ok
+}
+
+var germanNames = map[string]string{
+	"januar":     "January",
+	"jänner":     "January",
+	"februar":    "February",
+	"märz":       "March",
+	"april":      "April",
+	"mai":        "May",
+	"juni":       "June",
+	"juli":       "July",
+	"august":     "August",
+	"september":  "September",
+	"oktober":    "October",
+	"november":   "November",
+	"dezember":   "December",
+	"jan":        "Jan",
+	"feb":        "Feb",
+	"mär":        "Mar",
+	"mrz":        "Mar",
+	"apr":        "Apr",
+	"jun":        "Jun",
+	"jul":        "Jul",
+	"aug":        "Aug",
+	"sep":        "Sep",
+	"okt":        "Oct",
+	"nov":        "Nov",
+	"dez":        "Dec",
+	"montag":     "Monday",
+	"dienstag":   "Tuesday",
+	"mittwoch":   "Wednesday",
+	"donnerstag": "Thursday",
+	"freitag":    "Friday",
+	"samstag":    "Saturday",
+	"sonnabend":  "Saturday",
+	"sonntag":    "Sunday",
+	"mo":         "Mon",
+	"di":         "Tue",
+	"mi":         "Wed",
+	"do":         "Thu",
+	"fr":         "Fri",
+	"sa":         "Sat",
+	"so":         "Sun",
+}
+
+var frenchNames = map[string]string{
+	"janvier":   "January",
+	"février":   "February",
+	"fevrier":   "February",
+	"mars":      "March",
+	"avril":     "April",
+	"mai":       "May",
+	"juin":      "June",
+	"juillet":   "July",
+	"août":      "August",
+	"aout":      "August",
+	"septembre": "September",
+	"octobre":   "October",
+	"novembre":  "November",
+	"décembre":  "December",
+	"decembre":  "December",
+	"janv":      "Jan",
+	"févr":      "Feb",
+	"fevr":      "Feb",
+	"avr":       "Apr",
+	"juil":      "Jul",
+	"sept":      "Sep",
+	"déc":       "Dec",
+	"lundi":     "Monday",
+	"mardi":     "Tuesday",
+	"mercredi":  "Wednesday",
+	"jeudi":     "Thursday",
+	"vendredi":  "Friday",
+	"samedi":    "Saturday",
+	"dimanche":  "Sunday",
+}
+
+func unknownErr(datestr string) error {
+	return &ParseError{
+		Input:    datestr,
+		Position: -1,
+		Reason:   "unknown format",
+		msg:      fmt.Sprintf("Could not find format for %q", datestr),
+	}
+}
+
+// ParseError is the error of ParseAny and ParseIn for a date-string that
+// could not be parsed, locating the part that failed.  The errors of
+// options, such as ErrNotBusinessDay, are returned as they are.
 //
-//     t, err := dateparse.ParseLocal("3/1/2014")
+//     _, err := dateparse.ParseAny("2014-13-13 08:20:13,787")
+//     if pe, ok := err.(*dateparse.ParseError); ok {
+//         // pe.Reason = "month out of range", pe.Position = 5, pe.Length = 2
+//     }
 //
-// Equivalent to:
+type ParseError struct {
+	// Input is the date-string.
+	Input string
+	// Position is the byte offset in Input of the part that failed, or
+	// -1 if it is not known.
+	Position int
+	// Length is the length in bytes of the part that failed.
+	Length int
+	// Reason is what failed, such as "month out of range".
+	Reason string
+	// Err is the *time.ParseError of the layout detected, if any.
+	Err error
+	msg string
+}
+
+// Error is the message of the failure, the one time.Parse gives for the
+// layout detected.
+func (e *ParseError) Error() string {
+	return e.msg
+}
+
+// Unwrap is the *time.ParseError of the layout detected, if any.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// parseError is err, of parsing datestr, as a *ParseError locating the
+// part that failed by the fields lexed by p, if any.
+func parseError(datestr string, p *parser, err error) error {
+	switch e := err.(type) {
+	case *ParseError:
+		pe := *e
+		pe.Input = datestr
+		if len(e.Input) != len(datestr) {
+			// the part of a rewritten date-string
+			pe.Position, pe.Length = -1, 0
+		}
+		return &pe
+	case *time.ParseError:
+		pe := &ParseError{Input: datestr, Position: -1, Err: e, msg: e.Error()}
+		if e.Message != "" {
+			pe.Reason = strings.TrimPrefix(e.Message, ": ")
+		} else {
+			pe.Reason = fmt.Sprintf("cannot parse %q as %q", e.ValueElem, e.LayoutElem)
+		}
+		// the value parsed is the date-string less any skipped prefix, the
+		// same length when rewritten in place as 08:20:13,787 is
+		offset := len(datestr) - len(e.Value)
+		if offset < 0 || (p != nil && offset != p.skip) {
+			return pe
+		}
+		if field := strings.TrimSuffix(pe.Reason, " out of range"); field != pe.Reason {
+			if p != nil {
+				pe.Position, pe.Length = p.fieldSpan(field)
+			}
+			return pe
+		}
+		pe.Position, pe.Length = len(datestr)-len(e.ValueElem), len(e.ValueElem)
+		return pe
+	}
+	return err
+}
+
+// ParserOption defines a function signature implemented by options.
+// Options are applied to the parser before the date-string is read
+// and may change how it is interpreted.
+type ParserOption func(*parser) error
+
+// FieldOrder is the order of the year, month and day fields in an
+// all-numeric date such as 02/01/2014 where the layout alone can not
+// tell which field is which.
+type FieldOrder uint8
+
+const (
+	// OrderMDY is month/day/year, the default (US style).
+	OrderMDY FieldOrder = iota
+	// OrderDMY is day/month/year (European style).
+	OrderDMY
+	// OrderYMD is year/month/day with a two digit leading year.
+	OrderYMD
+)
+
+// PrecisionMode is how WithPrecision reduces the sub-second precision
+// of the parsed time.
+type PrecisionMode uint8
+
+const (
+	// Truncate drops the excess precision, the default.
+	Truncate PrecisionMode = iota
+	// Round rounds to the nearest multiple, halfway values up.
+	Round
+)
+
+// WeekdayDirection is which occurrence of a standalone weekday, Friday,
+// a relative date-string resolves to.
+type WeekdayDirection uint8
+
+const (
+	// Next is the first occurrence after the base date, the default.
+	Next WeekdayDirection = iota
+	// Previous is the last occurrence before the base date.
+	Previous
+	// Nearest is the closest occurrence, the base date itself included.
+	Nearest
+)
+
+// fieldOrder sets the order used to resolve ambiguous numeric dates, a
+// date not valid in it being an error even after PreferDayFirst.
+func fieldOrder(order FieldOrder) ParserOption {
+	return func(p *parser) error {
+		p.order = order
+		p.orderSet = true
+		p.orderPreferred = false
+		return nil
+	}
+}
+
+// WithBusinessDaysOnly is an option that rejects dates falling on a
+// Saturday, Sunday or any of the given holidays with ErrNotBusinessDay.
+// Holidays are compared by date only, the time of day is ignored.
+func WithBusinessDaysOnly(holidays []time.Time) ParserOption {
+	return func(p *parser) error {
+		p.after = append(p.after, func(t time.Time) (time.Time, error) {
+			switch t.Weekday() {
+			case time.Saturday, time.Sunday:
+				return t, ErrNotBusinessDay
+			}
+			y, m, d := t.Date()
+			for _, h := range holidays {
+				hy, hm, hd := h.Date()
+				if y == hy && m == hm && d == hd {
+					return t, ErrNotBusinessDay
+				}
+			}
+			return t, nil
+		})
+		return nil
+	}
+}
+
+// WithLanguage is an option that sets the language of the date-string.
+// Supported are "en", the default, "fr" for french month and weekday
+// names and the hour notation 17h30, 17 h 30 and 17 h, and "de" for
+// german month and weekday names, Montag, 26. April 2014, "ja" and "zh"
+// for the weekday annotation of 2014-04-26(土), see ValidateWeekday.  French
+// numeric dates are day first, 02/01/2014 is 2 January, unless
+// PreferDayFirst(false) is given.  A leading weekday name is checked under
+// ValidateWeekday.  As time.Parse only reads english names the layout of
+// a date-string with a localized month name, 26. März 2014, is that of its
+// english translation.
+func WithLanguage(lang string) ParserOption {
+	return func(p *parser) error {
+		if _, ok := languages[lang]; !ok {
+			return fmt.Errorf("Unsupported language %q", lang)
+		}
+		p.lang = lang
+		return nil
+	}
+}
+
+// WithMonthNamesOverride is an option that replaces the english month
+// names with names, keyed by the lower case name, so a date-string in a
+// single other language never matches an english name by accident.  An
+// english month name not in names is an error, a nil names restores the
+// english names.
 //
-//     t, err := dateparse.ParseIn("3/1/2014", denverLoc)
+//     names := map[string]time.Month{"enero": time.January, "ene": time.January}
+//     t, err := dateparse.ParseAny("26 enero 2014", dateparse.WithMonthNamesOverride(names))
 //
-func ParseLocal(datestr string) (time.Time, error) {
-	p, err := parseTime(datestr, time.Local)
-	if err != nil {
-		return time.Time{}, err
+func WithMonthNamesOverride(names map[string]time.Month) ParserOption {
+	return func(p *parser) error {
+		p.monthNames = names
+		return nil
 	}
-	return p.parse()
 }
 
-// MustParse  parse a date, and panic if it can't be parsed.  Used for testing.
-// Not recommended for most use-cases.
-func MustParse(datestr string) time.Time {
-	p, err := parseTime(datestr, nil)
-	if err != nil {
-		panic(err.Error())
+// WithPreserveZoneName is an option that keeps the zone name following
+// a numeric offset, 03:02:00 +0300 MSK, as the name of the location of
+// the parsed time so ts.Zone() reports MSK.  Well known zone names that
+// do not match the offset are only an error with WithZoneConflictCheck.
+func WithPreserveZoneName(preserve bool) ParserOption {
+	return func(p *parser) error {
+		if !preserve {
+			return nil
+		}
+		p.after = append(p.after, func(t time.Time) (time.Time, error) {
+			name, offset := t.Zone()
+			if name == "" {
+				name = p.zoneName()
+			}
+			if name == "" {
+				return t, nil
+			}
+			return t.In(time.FixedZone(name, offset)), nil
+		})
+		return nil
 	}
-	t, err := p.parse()
-	if err != nil {
-		panic(err.Error())
+}
+
+// PreferDayFirst is an option that reads an ambiguous numeric date such
+// as 04/02/2014 as day first, 4 February, rather than the default month
+// first, 2 April.  Either way a date only valid in the other order, as
+// 13/02/2014 or 02/13/2014, is read in that order, but for the dashes of
+// 29-06-2016 which are only read day first when preferred.  Day first a
+// well known zone name without an offset, as in 26/04/2014 17:24:37 IST,
+// is given its offset, which the layout, leaving the name as text, does
+// not reproduce.
+func PreferDayFirst(dayFirst bool) ParserOption {
+	return func(p *parser) error {
+		if dayFirst {
+			p.order = OrderDMY
+		} else {
+			p.order = OrderMDY
+		}
+		p.orderSet = true
+		p.orderPreferred = true
+		return nil
 	}
-	return t
 }
 
-// ParseFormat parse's an unknown date-time string and returns a layout
-// string that can parse this (and exact same format) other date-time strings.
+// WithColumnInference is an option for ParseSlice and ParseSliceFormats
+// that reads the ambiguous numeric dates of a column in the order proven
+// by its other rows: 13/02/2014 only parses day first, so 01/02/2014 in
+// the same column is 1 February too.  A column with rows proving both
+// orders, or neither, is resolved per row as usual.  Parsing a single
+// date-string ignores it.
+func WithColumnInference(infer bool) ParserOption {
+	return func(p *parser) error {
+		p.columnInference = infer
+		return nil
+	}
+}
+
+// WithCompactYearMonth is an option that reads a six digit date-string
+// as the ISO 8601 reduced year and month, 201404 for April 2014.  It is
+// off by default as six digits are as likely to be something else.
+func WithCompactYearMonth(compact bool) ParserOption {
+	return func(p *parser) error {
+		p.compactYearMonth = compact
+		return nil
+	}
+}
+
+// WithClock is an option that sets the clock relative and partial
+// date-strings are resolved from, in place of the package wide
+// SetNowFunc.  A WithBaseDate date still takes precedence.
+func WithClock(clock Clock) ParserOption {
+	return func(p *parser) error {
+		p.clock = clock
+		return nil
+	}
+}
+
+// WithDotTime is an option that reads dots as the time separator of the
+// T separated ISO 8601 form, 2014-04-26T15.30.00Z, as in file names that
+// can not hold a colon.
+func WithDotTime(dotTime bool) ParserOption {
+	return func(p *parser) error {
+		p.dotTime = dotTime
+		return nil
+	}
+}
+
+// WithPlusAsDateTimeSeparator is an option that reads a + between the
+// date and a time with seconds, 2014-04-26+17:24:37, as the separator
+// rather than an offset.  A +05:00 without seconds stays an offset.
+func WithPlusAsDateTimeSeparator(plus bool) ParserOption {
+	return func(p *parser) error {
+		p.plusSeparator = plus
+		return nil
+	}
+}
+
+// WithSpacePadding is an option that reads a month or day padded with a
+// space, 2014/ 4/ 8, 2014- 4- 8 or 2014/ 12/ 8, as zero padded.  Without
+// it they are an error, as is a field padded with more than one space.
+// The layout of a padded day is _2, of a padded month a space before the 1.
+func WithSpacePadding(padding bool) ParserOption {
+	return func(p *parser) error {
+		p.spacePadding = padding
+		return nil
+	}
+}
+
+// WithGoLayoutTokens is an option that reads the Z0700 or Z07:00 of a Go
+// layout, leaked into 2014-04-26 15:04:05Z0700 by a serialization bug, as
+// the Z it renders for UTC.
+func WithGoLayoutTokens(tokens bool) ParserOption {
+	return func(p *parser) error {
+		p.goLayoutTokens = tokens
+		return nil
+	}
+}
+
+// WithEightDigitOrder is an option that sets the order of the fields of
+// an eight digit date-string, OrderYMD for 20140601, the default,
+// OrderDMY for 01062014 or OrderMDY for 06012014.
+func WithEightDigitOrder(order FieldOrder) ParserOption {
+	return func(p *parser) error {
+		switch order {
+		case OrderYMD, OrderDMY, OrderMDY:
+		default:
+			return fmt.Errorf("Invalid eight digit order %d", order)
+		}
+		p.eightDigitOrder = order
+		return nil
+	}
+}
+
+// WithAssumeCentury is an option for a year of 1 to 3 digits, as OCR
+// dropping a digit of 2014 leaves 04/26/214 or 04/26/14.  The year is the
+// four digit year nearest the year of the base date, see WithBaseDate,
+// whose digits hold the short year's digits in order, the earlier year
+// of a tie.  Near 2014 the years 214 and 14 are 2014, 99 is 1999, 31 is
+// 2031 and 7 is 2017.  Otherwise two digit years are 1969 to 2068 and
+// shorter ones an error.
+func WithAssumeCentury(assume bool) ParserOption {
+	return func(p *parser) error {
+		p.assumeCentury = assume
+		return nil
+	}
+}
+
+// WithHeuristicsDisabled is an option that parses only the layouts of
+// RegisterLayout and the unambiguous formats, rejecting the guessed ones
+// such as 01/02/2014 or an epoch timestamp.  The formats that remain are
 //
-//     layout, err := dateparse.ParseFormat("2013-02-01 00:00:00")
-//     // layout = "2006-01-02 15:04:05"
+//     ISO 8601        as ParseISO reads it, 2014-04-26T17:24:37Z
+//                     2014-04-26, 20140426, 2014-W17-6 or 2014-116
+//     RFC 3339        2014-04-26T17:24:37.123-07:00
+//     RFC 1123        Sat, 26 Apr 2014 17:24:37 MST or -0700
 //
-func ParseFormat(datestr string) (string, error) {
-	p, err := parseTime(datestr, nil)
-	if err != nil {
-		return "", err
+func WithHeuristicsDisabled(disabled bool) ParserOption {
+	return func(p *parser) error {
+		p.noHeuristics = disabled
+		return nil
 	}
-	_, err = p.parse()
-	if err != nil {
-		return "", err
+}
+
+// ValidateWeekday is an option that rejects a date-string whose weekday
+// annotation, 2014-04-26 (Sat) or 2014-04-26(土) under the "ja" language,
+// is not the weekday of the date, as is the relative Monday the 26th of
+// EnableRelative and the translated Montag, 26. April 2014 of WithLanguage.
+// By default the annotation is skipped.
+func ValidateWeekday(validate bool) ParserOption {
+	return func(p *parser) error {
+		p.validateWeekday = validate
+		return nil
 	}
-	return string(p.format), nil
 }
 
-// ParseStrict parse an unknown date format.  IF the date is ambigous
-// mm/dd vs dd/mm then return an error. These return errors:   3.3.2014 , 8/8/71 etc
-func ParseStrict(datestr string) (time.Time, error) {
-	p, err := parseTime(datestr, nil)
-	if err != nil {
-		return time.Time{}, err
+// checkWeekday rejects a parsed time not on day.
+func checkWeekday(day time.Weekday) ParserOption {
+	return func(p *parser) error {
+		p.after = append(p.after, func(t time.Time) (time.Time, error) {
+			if t.Weekday() != day {
+				return t, fmt.Errorf("%s is not a %s in %q", t.Format("2006-01-02"), day, p.datestr)
+			}
+			return t, nil
+		})
+		return nil
 	}
-	if p.ambiguousMD {
-		return time.Time{}, ErrAmbiguousMMDD
+}
+
+// relayout maps the layout detected for ds, the rewrite of datestr, to a
+// layout of datestr with fn.  A layout not parsing datestr to the time ds
+// is parsed to is dropped for the layout of ds.
+func relayout(datestr, ds string, fn func(layout, ds, datestr string) string) ParserOption {
+	return func(p *parser) error {
+		p.relayouts = append(p.relayouts, func(layout string) string {
+			mapped := fn(layout, ds, datestr)
+			want, err := time.Parse(layout, ds)
+			if err != nil {
+				return layout
+			}
+			if got, err := time.Parse(mapped, datestr); err != nil || !got.Equal(want) {
+				return layout
+			}
+			return mapped
+		})
+		return nil
 	}
-	return p.parse()
 }
 
-func parseTime(datestr string, loc *time.Location) (*parser, error) {
+// WithLocalDesignator is an option that reads a trailing L after the
+// time, 2014-04-26T17:24:37L, as local time without an offset, in the
+// location given to ParseIn or WithLocation.
+func WithLocalDesignator(local bool) ParserOption {
+	return func(p *parser) error {
+		p.localDesignator = local
+		return nil
+	}
+}
 
-	p := newParser(datestr, loc)
-	i := 0
+// StrictRFC3339 is an option that accepts only RFC 3339 date-strings,
+// 2006-01-02T15:04:05Z07:00 with an optional fraction of a second, and
+// rejects any other format ParseAny would read, such as a space in place
+// of the T or a missing offset.
+func StrictRFC3339(strict bool) ParserOption {
+	return func(p *parser) error {
+		p.strictRFC3339 = strict
+		return nil
+	}
+}
 
-	// General strategy is to read rune by rune through the date looking for
-	// certain hints of what type of date we are dealing with.
-	// Hopefully we only need to read about 5 or 6 bytes before
-	// we figure it out and then attempt a parse
-iterRunes:
-	for ; i < len(datestr); i++ {
-		//r := rune(datestr[i])
-		r, bytesConsumed := utf8.DecodeRuneInString(datestr[i:])
-		if bytesConsumed > 1 {
-			i += (bytesConsumed - 1)
+// WithTrimTrailingPunctuation is an option that drops the punctuation
+// ending a date taken from a sentence, "2014-04-26." or "April 26,
+// 2014!".  The period of an abbreviation, 26 Jan., is kept.
+func WithTrimTrailingPunctuation(trim bool) ParserOption {
+	return func(p *parser) error {
+		p.trimPunctuation = trim
+		return nil
+	}
+}
+
+// WithParseHook is an option that applies hook to every parsed time
+// before it is returned, an error from hook failing the parse.  Hooks and
+// the options adjusting the time, such as WithPrecision, apply in the
+// order given.
+//
+//     utc := dateparse.WithParseHook(func(t time.Time) (time.Time, error) {
+//         return t.UTC(), nil
+//     })
+//
+func WithParseHook(hook func(time.Time) (time.Time, error)) ParserOption {
+	return func(p *parser) error {
+		if hook != nil {
+			p.after = append(p.after, hook)
+		}
+		return nil
+	}
+}
+
+// WithBaseDate is an option that sets the date that partial and relative
+// date-strings are resolved from, the year of 04/26 or the next Friday.
+// The default is the current time, see WithClock.
+func WithBaseDate(base time.Time) ParserOption {
+	return func(p *parser) error {
+		p.baseDate = base
+		return nil
+	}
+}
+
+// WithPrecision is an option that reduces the parsed time to a multiple
+// of d, time.Second drops the fraction of 2009-08-12T22:15:09.6Z.  By
+// default the excess is truncated, see WithPrecisionMode.
+func WithPrecision(d time.Duration) ParserOption {
+	return func(p *parser) error {
+		if d <= 0 {
+			return nil
 		}
+		p.after = append(p.after, func(t time.Time) (time.Time, error) {
+			if p.precisionMode == Round {
+				return t.Round(d), nil
+			}
+			return t.Truncate(d), nil
+		})
+		return nil
+	}
+}
 
-		//gou.Debugf("i=%d r=%s state=%d   %s", i, string(r), p.stateDate, datestr)
-		switch p.stateDate {
-		case dateStart:
-			if unicode.IsDigit(r) {
-				p.stateDate = dateDigit
-			} else if unicode.IsLetter(r) {
-				p.stateDate = dateAlpha
-			} else {
-				return nil, unknownErr(datestr)
+// WithDateOnly is an option that discards the parsed time of day and
+// returns midnight of the parsed date, in the location of the result so
+// 2014-04-26T23:59:59-07:00 stays on 2014-04-26 rather than moving to
+// the next day in UTC.
+func WithDateOnly(dateOnly bool) ParserOption {
+	return func(p *parser) error {
+		if !dateOnly {
+			return nil
+		}
+		p.after = append(p.after, func(t time.Time) (time.Time, error) {
+			y, m, d := t.Date()
+			return time.Date(y, m, d, 0, 0, 0, 0, t.Location()), nil
+		})
+		return nil
+	}
+}
+
+// WithYearBounds is an option that rejects a parsed time whose year is
+// outside min to max inclusive, a garbage year from corrupt data rather
+// than an absurd time.  A zero min or max is the default of 1 or 9999.
+func WithYearBounds(min, max int) ParserOption {
+	return func(p *parser) error {
+		if min == 0 {
+			min = 1
+		}
+		if max == 0 {
+			max = 9999
+		}
+		if min > max {
+			return fmt.Errorf("Invalid year bounds %d to %d", min, max)
+		}
+		p.after = append(p.after, func(t time.Time) (time.Time, error) {
+			if year := t.Year(); year < min || year > max {
+				return time.Time{}, fmt.Errorf("The year %d of %q is outside %d to %d", year, p.datestr, min, max)
 			}
-		case dateDigit:
+			return t, nil
+		})
+		return nil
+	}
+}
 
-			switch r {
-			case '-', '\u2212':
-				// 2006-01-02
-				// 2013-Feb-03
-				// 13-Feb-03
-				// 29-Jun-2016
-				if i == 4 {
-					p.stateDate = dateYearDash
-					p.yeari = 0
-					p.yearlen = i
-					p.moi = i + 1
-					p.set(0, "2006")
-				} else {
-					p.stateDate = dateDigitDash
-				}
-			case '/':
-				// 03/31/2005
-				// 2014/02/24
-				p.stateDate = dateDigitSlash
-				if i == 4 {
-					p.yearlen = i
-					p.moi = i + 1
-					p.setYear()
-				} else {
-					p.ambiguousMD = true
-					if p.preferMonthFirst {
-						if p.molen == 0 {
-							p.molen = i
-							p.setMonth()
-							p.dayi = i + 1
-						}
-					}
+// WithPrecisionMode is an option that sets whether WithPrecision
+// truncates, the default, or rounds the parsed time.
+func WithPrecisionMode(mode PrecisionMode) ParserOption {
+	return func(p *parser) error {
+		p.precisionMode = mode
+		return nil
+	}
+}
+
+// WithAllowedSeparators is an option that rejects a numeric date using
+// a separator, - / or ., not in seps.  WithAllowedSeparators('-', '/')
+// rejects 04.26.2014.  By default all of them are accepted.
+func WithAllowedSeparators(seps ...rune) ParserOption {
+	return func(p *parser) error {
+		p.after = append(p.after, func(t time.Time) (time.Time, error) {
+			if p.dateSep == 0 {
+				return t, nil
+			}
+			for _, sep := range seps {
+				if sep == p.dateSep {
+					return t, nil
 				}
+			}
+			return t, fmt.Errorf("Date separator %q not allowed in %q", p.dateSep, p.datestr)
+		})
+		return nil
+	}
+}
 
-			case '.':
-				// 3.31.2014
-				// 08.21.71
-				// 2014.05
-				p.stateDate = dateDigitDot
-				if i == 4 {
-					p.yearlen = i
-					p.moi = i + 1
-					p.setYear()
-				} else {
-					p.ambiguousMD = true
-					p.moi = 0
-					p.molen = i
-					p.setMonth()
-					p.dayi = i + 1
-				}
+// WithLocation is an option that sets the location used for zone names
+// and offset-less date-strings, the same as the location of ParseIn.
+func WithLocation(loc *time.Location) ParserOption {
+	return func(p *parser) error {
+		p.loc = loc
+		return nil
+	}
+}
 
-			case ' ':
-				// 18 January 2018
-				// 8 January 2018
-				// 8 jan 2018
-				// 02 Jan 2018 23:59
-				// 02 Jan 2018 23:59:34
-				// 12 Feb 2006, 19:17
-				// 12 Feb 2006, 19:17:22
-				p.stateDate = dateDigitWs
-				p.dayi = 0
-				p.daylen = i
-			case '年':
-				// Chinese Year
-				p.stateDate = dateDigitChineseYear
-			case ',':
-				return nil, unknownErr(datestr)
-			default:
-				continue
-			}
-			p.part1Len = i
+// AssumeLocalIfZoneless is an option that resolves a date-string without
+// offset or zone in time.Local, or the WithLocation location, while one
+// carrying an offset or zone is honored as-is.  This is the ParseLocal
+// behavior for any of the parse functions.
+func AssumeLocalIfZoneless(assume bool) ParserOption {
+	return func(p *parser) error {
+		if assume && p.loc == nil {
+			p.loc = time.Local
+		}
+		return nil
+	}
+}
 
-		case dateYearDash:
-			// dateYearDashDashT
-			//  2006-01-02T15:04:05Z07:00
-			// dateYearDashDashWs
-			//  2013-04-01 22:43:22
-			// dateYearDashAlphaDash
-			//   2013-Feb-03
-			switch r {
-			case '-':
-				p.molen = i - p.moi
-				p.dayi = i + 1
-				p.stateDate = dateYearDashDash
-				p.setMonth()
-			default:
-				if unicode.IsLetter(r) {
-					p.stateDate = dateYearDashAlphaDash
-				}
-			}
+// WithWeekStart is an option that sets the first day of the week for
+// "Week 15 2014 Monday" dates.  The default time.Monday follows the
+// ISO 8601 week rules, any other day numbers the weeks from the one
+// holding January 1st.
+func WithWeekStart(day time.Weekday) ParserOption {
+	return func(p *parser) error {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("Invalid week start %d", day)
+		}
+		p.weekStart = day
+		return nil
+	}
+}
 
-		case dateYearDashDash:
-			// dateYearDashDashT
-			//  2006-01-02T15:04:05Z07:00
-			// dateYearDashDashWs
-			//  2013-04-01 22:43:22
-			switch r {
-			case ' ':
-				p.daylen = i - p.dayi
-				p.stateDate = dateYearDashDashWs
-				p.stateTime = timeStart
-				p.setDay()
-				break iterRunes
-			case 'T':
-				p.daylen = i - p.dayi
-				p.stateDate = dateYearDashDashT
-				p.stateTime = timeStart
-				p.setDay()
-				break iterRunes
-			}
-		case dateYearDashAlphaDash:
-			// 2013-Feb-03
-			switch r {
-			case '-':
-				p.molen = i - p.moi
-				p.set(p.moi, "Jan")
-				p.dayi = i + 1
-			}
-		case dateDigitDash:
-			// 13-Feb-03
-			// 29-Jun-2016
-			if unicode.IsLetter(r) {
-				p.stateDate = dateDigitDashAlpha
-				p.moi = i
-			} else {
-				return nil, unknownErr(datestr)
+// WithPedanticRanges is an option that checks the hour, minute and second
+// of the date-string are within 0-23 and 0-59 before the layout is parsed,
+// reporting the field out of range rather than relying on the layout
+// parse or a normalizing time.Date.  A leap second 60 can not be held by
+// time.Time so it is an error too.
+func WithPedanticRanges(pedantic bool) ParserOption {
+	return func(p *parser) error {
+		p.pedanticRanges = pedantic
+		return nil
+	}
+}
+
+// WithZoneConflictCheck is an option that rejects a date-string whose
+// zone name, a known abbreviation such as BST, is for a different offset
+// than the numeric one, 2016-06-21T19:55:00+05:00 BST.  Otherwise a
+// name following an offset is ignored.
+func WithZoneConflictCheck(check bool) ParserOption {
+	return func(p *parser) error {
+		if !check {
+			return nil
+		}
+		p.after = append(p.after, func(t time.Time) (time.Time, error) {
+			if p.offseti == 0 && p.stateTime != timeZ {
+				return t, nil
 			}
-		case dateDigitDashAlpha:
-			// 13-Feb-03
-			// 28-Feb-03
-			// 29-Jun-2016
-			switch r {
-			case '-':
-				p.molen = i - p.moi
-				p.set(p.moi, "Jan")
-				p.yeari = i + 1
-				p.stateDate = dateDigitDashAlphaDash
+			name := p.zoneName()
+			_, offset := t.Zone()
+			if known, ok := zoneOffsets[name]; ok && known != offset {
+				return t, fmt.Errorf("Zone %s does not match offset in %q", name, p.datestr)
 			}
+			return t, nil
+		})
+		return nil
+	}
+}
 
-		case dateDigitDashAlphaDash:
-			// 13-Feb-03   ambiguous
-			// 28-Feb-03   ambiguous
-			// 29-Jun-2016
-			switch r {
-			case ' ':
-				// we need to find if this was 4 digits, aka year
-				// or 2 digits which makes it ambiguous year/day
-				length := i - (p.moi + p.molen + 1)
-				if length == 4 {
-					p.yearlen = 4
-					p.set(p.yeari, "2006")
-					// We now also know that part1 was the day
-					p.dayi = 0
-					p.daylen = p.part1Len
-					p.setDay()
-				} else if length == 2 {
-					// We have no idea if this is
-					// yy-mon-dd   OR  dd-mon-yy
-					//
-					// We are going to ASSUME (bad, bad) that it is dd-mon-yy  which is a horible assumption
-					p.ambiguousMD = true
-					p.yearlen = 2
-					p.set(p.yeari, "06")
-					// We now also know that part1 was the day
-					p.dayi = 0
-					p.daylen = p.part1Len
-					p.setDay()
-				}
-				p.stateTime = timeStart
-				break iterRunes
+// EnableRelative is an option that enables relative date expressions:
+//
+//     epoch           1970-01-01 00:00:00 UTC
+//     epoch+86400     seconds after the unix epoch
+//     epoch-1h        any time.ParseDuration offset from the unix epoch
+//     now             the base date, see WithBaseDate
+//     today           midnight of the base date
+//     yesterday       midnight of the day before the base date
+//     tomorrow        midnight of the day after the base date
+//     Friday          midnight of the next Friday, see WithWeekdayDirection
+//     Day 103 of 2014 the ordinal day of the year, optionally with a time
+//     1430 hrs        the military time of day of the base date
+//     1430Z           the same in a military zone, Z for UTC or A to Y
+//     Monday the 26th the day of the month of the base date, see
+//                     ValidateWeekday
+//     2014-04 EOM     the last day of the month of a date, 2014-04-30
+//     2014-04 BOM     the first day of the month of a date, 2014-04-01
+//
+func EnableRelative(relative bool) ParserOption {
+	return func(p *parser) error {
+		p.relative = relative
+		return nil
+	}
+}
+
+// monthBoundary moves a parsed time to the last day of its month, or
+// the first day when not end.
+func monthBoundary(end bool) ParserOption {
+	return func(p *parser) error {
+		p.after = append(p.after, func(t time.Time) (time.Time, error) {
+			day := 1
+			if end {
+				// day 0 of the next month is the last of this one
+				day = time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
 			}
+			return time.Date(t.Year(), t.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()), nil
+		})
+		return nil
+	}
+}
 
-		case dateDigitSlash:
-			// 2014/07/10 06:55:38.156283
-			// 03/19/2012 10:11:59
-			// 04/2/2014 03:00:37
-			// 3/1/2012 10:11:59
-			// 4/8/2014 22:05
-			// 3/1/2014
-			// 10/13/2014
-			// 01/02/2006
-			// 1/2/06
+// WithASCIIOnly is an option that rejects a date-string holding any
+// non-ASCII byte, so the Chinese 2014年04月08日 and other multi-byte
+// forms are never tried.
+func WithASCIIOnly(asciiOnly bool) ParserOption {
+	return func(p *parser) error {
+		p.asciiOnly = asciiOnly
+		return nil
+	}
+}
 
-			switch r {
-			case ' ':
-				p.stateTime = timeStart
-				if p.yearlen == 0 {
-					p.yearlen = i - p.yeari
-					p.setYear()
-				} else if p.daylen == 0 {
-					p.daylen = i - p.dayi
-					p.setDay()
-				}
-				break iterRunes
-			case '/':
-				if p.yearlen > 0 {
-					// 2014/07/10 06:55:38.156283
-					if p.molen == 0 {
-						p.molen = i - p.moi
-						p.setMonth()
-						p.dayi = i + 1
-					}
-				} else if p.preferMonthFirst {
-					if p.daylen == 0 {
-						p.daylen = i - p.dayi
-						p.setDay()
-						p.yeari = i + 1
-					}
-				}
-			}
+// WithLenientSeconds is an option that fills the missing hour of a time,
+// the :24:37 of 2014-04-26 :24:37, with 00 so its layout has the hour as
+// well, 15:04:05.  Without it the time is read as 00:24:37 all the same
+// but the layout is :04:05.
+func WithLenientSeconds(lenient bool) ParserOption {
+	return func(p *parser) error {
+		p.lenientSeconds = lenient
+		return nil
+	}
+}
 
-		case dateDigitWs:
-			// 18 January 2018
-			// 8 January 2018
-			// 8 jan 2018
-			// 1 jan 18
-			// 02 Jan 2018 23:59
-			// 02 Jan 2018 23:59:34
-			// 12 Feb 2006, 19:17
-			// 12 Feb 2006, 19:17:22
-			switch r {
-			case ' ':
-				p.yeari = i + 1
-				//p.yearlen = 4
-				p.dayi = 0
-				p.daylen = p.part1Len
-				p.setDay()
-				p.stateTime = timeStart
-				if i > p.daylen+len(" Sep") { //  November etc
-					// If len greather than space + 3 it must be full month
-					p.stateDate = dateDigitWsMolong
-				} else {
-					// If len=3, the might be Feb or May?  Ie ambigous abbreviated but
-					// we can parse may with either.  BUT, that means the
-					// format may not be correct?
-					// mo := strings.ToLower(datestr[p.daylen+1 : i])
-					p.moi = p.daylen + 1
-					p.molen = i - p.moi
-					p.set(p.moi, "Jan")
-					p.stateDate = dateDigitWsMoYear
-				}
-			}
+// WithFlexibleEpoch is an option that accepts an epoch timestamp with
+// thousands separators, 1,384,216,367, which is otherwise an error.
+func WithFlexibleEpoch(flexible bool) ParserOption {
+	return func(p *parser) error {
+		p.flexibleEpoch = flexible
+		return nil
+	}
+}
 
-		case dateDigitWsMoYear:
-			// 8 jan 2018
-			// 02 Jan 2018 23:59
-			// 02 Jan 2018 23:59:34
-			// 12 Feb 2006, 19:17
-			// 12 Feb 2006, 19:17:22
-			switch r {
-			case ',':
-				p.yearlen = i - p.yeari
-				p.setYear()
-				i++
-				break iterRunes
-			case ' ':
-				p.yearlen = i - p.yeari
-				p.setYear()
-				break iterRunes
+// WithSentinels is an option that maps sentinel date-strings, such as
+// 9999-12-31 for no end date or 0000-00-00 for null, to the given times
+// instead of parsing them.  The date-string must match a key exactly.
+//
+//     t, err := dateparse.ParseAny("0000-00-00", dateparse.WithSentinels(map[string]time.Time{
+//         "0000-00-00": time.Time{},
+//     }))
+//     // t.IsZero() == true
+//
+func WithSentinels(sentinels map[string]time.Time) ParserOption {
+	return func(p *parser) error {
+		p.sentinels = sentinels
+		return nil
+	}
+}
+
+// WithWeekdayDirection is an option that sets which occurrence of a
+// standalone weekday a relative date-string resolves to, counted from
+// the WithBaseDate date.
+func WithWeekdayDirection(direction WeekdayDirection) ParserOption {
+	return func(p *parser) error {
+		p.weekdayDirection = direction
+		return nil
+	}
+}
+
+// WithKeepFractionDigits is an option that keeps the number of fractional
+// second digits of the date-string, trailing zeros included, when the
+// result is formatted by Normalize.  The parsed time is identical either
+// way, only the output of the formatting helpers changes.
+func WithKeepFractionDigits(keep bool) ParserOption {
+	return func(p *parser) error {
+		p.keepFractionDigits = keep
+		return nil
+	}
+}
+
+// WithMaxFractionDigits is an option that rejects a date-string with
+// more than n fractional second digits, 09.123456789 for n of 6, where
+// by default any precision up to nanoseconds is accepted.
+func WithMaxFractionDigits(n int) ParserOption {
+	return func(p *parser) error {
+		if n < 0 {
+			return fmt.Errorf("Invalid max fraction digits %d", n)
+		}
+		p.after = append(p.after, func(t time.Time) (time.Time, error) {
+			if p.mslen > n {
+				return t, fmt.Errorf("More than %d fractional second digits in %q", n, p.datestr)
 			}
-		case dateDigitWsMolong:
-			// 18 January 2018
-			// 8 January 2018
+			return t, nil
+		})
+		return nil
+	}
+}
 
-		case dateDigitChineseYear:
-			// dateDigitChineseYear
-			//   2014年04月08日
-			//               weekday  %Y年%m月%e日 %A %I:%M %p
-			// 2013年07月18日 星期四 10:27 上午
-			if r == ' ' {
-				p.stateDate = dateDigitChineseYearWs
-				break
+// WithRequireFourDigitYear is an option that rejects date-strings with
+// a two digit year, 08/21/71, rather than guessing the century.
+func WithRequireFourDigitYear(require bool) ParserOption {
+	return func(p *parser) error {
+		if !require {
+			return nil
+		}
+		p.after = append(p.after, func(t time.Time) (time.Time, error) {
+			if p.t == nil && p.twoDigitYear() {
+				return t, fmt.Errorf("Two digit year is not allowed in %q", p.datestr)
 			}
-		case dateDigitDot:
-			// This is the 2nd period
-			// 3.31.2014
-			// 08.21.71
-			// 2014.05
-			// 2018.09.30
-			if r == '.' {
-				if p.moi == 0 {
-					// 3.31.2014
-					p.daylen = i - p.dayi
-					p.yeari = i + 1
-					p.setDay()
-					p.stateDate = dateDigitDotDot
-				} else {
-					// 2018.09.30
-					//p.molen = 2
-					p.molen = i - p.moi
-					p.dayi = i + 1
-					p.setMonth()
-					p.stateDate = dateDigitDotDot
-				}
+			return t, nil
+		})
+		return nil
+	}
+}
+
+// WithImpliedMillis is an option for producers that append milliseconds
+// to the seconds without a period, 17:24:37123.  When enabled, exactly
+// three digits directly following a two digit seconds field are read as
+// milliseconds.  Off by default.
+func WithImpliedMillis(implied bool) ParserOption {
+	return func(p *parser) error {
+		p.impliedMillis = implied
+		return nil
+	}
+}
+
+// ParseAny parse an unknown date format, detect the layout.
+// Normal parse.  Equivalent Timezone rules as time.Parse().
+// NOTE:  please see readme on mmdd vs ddmm ambiguous dates.
+func ParseAny(datestr string, opts ...ParserOption) (time.Time, error) {
+	p, err := parseTime(datestr, nil, opts...)
+	if err != nil {
+		return time.Time{}, parseError(datestr, nil, err)
+	}
+	t, err := p.parse()
+	if err != nil {
+		return time.Time{}, parseError(datestr, p, err)
+	}
+	return t, nil
+}
+
+// ParseIn with Location, equivalent to time.ParseInLocation() timezone/offset
+// rules.  Using location arg, if timezone/offset info exists in the
+// datestring, it uses the given location rules for any zone interpretation.
+// That is, MST means one thing when using America/Denver and something else
+// in other locations.
+func ParseIn(datestr string, loc *time.Location, opts ...ParserOption) (time.Time, error) {
+	p, err := parseTime(datestr, loc, opts...)
+	if err != nil {
+		return time.Time{}, parseError(datestr, nil, err)
+	}
+	t, err := p.parse()
+	if err != nil {
+		return time.Time{}, parseError(datestr, p, err)
+	}
+	return t, nil
+}
+
+// ParseLocal Given an unknown date format, detect the layout,
+// using time.Local, parse.
+//
+// Set Location to time.Local.  Same as ParseIn Location but lazily uses
+// the global time.Local variable for Location argument.
+//
+//     denverLoc, _ := time.LoadLocation("America/Denver")
+//     time.Local = denverLoc
+//
+//     t, err := dateparse.ParseLocal("3/1/2014")
+//
+// Equivalent to:
+//
+//     t, err := dateparse.ParseIn("3/1/2014", denverLoc)
+//
+func ParseLocal(datestr string, opts ...ParserOption) (time.Time, error) {
+	p, err := parseTime(datestr, time.Local, opts...)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return p.parse()
+}
+
+// MustParse  parse a date, and panic if it can't be parsed.  Used for testing.
+// Not recommended for most use-cases.
+func MustParse(datestr string, opts ...ParserOption) time.Time {
+	p, err := parseTime(datestr, nil, opts...)
+	if err != nil {
+		panic(err.Error())
+	}
+	t, err := p.parse()
+	if err != nil {
+		panic(err.Error())
+	}
+	return t
+}
+
+// ParseFormat parse's an unknown date-time string and returns a layout
+// string that can parse this (and exact same format) other date-time strings.
+//
+//     layout, err := dateparse.ParseFormat("2013-02-01 00:00:00")
+//     // layout = "2006-01-02 15:04:05"
+//
+func ParseFormat(datestr string, opts ...ParserOption) (string, error) {
+	p, err := parseTime(datestr, nil, opts...)
+	if err != nil {
+		return "", err
+	}
+	_, err = p.parse()
+	if err != nil {
+		return "", err
+	}
+	return p.layout(), nil
+}
+
+// ParseAnyWithFormat parse an unknown date format returning the time and
+// the layout detected for it in a single pass, ParseAny and ParseFormat
+// without detecting the format twice.
+func ParseAnyWithFormat(datestr string, opts ...ParserOption) (time.Time, string, error) {
+	p, err := parseTime(datestr, nil, opts...)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	t, err := p.parse()
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return t, p.layout(), nil
+}
+
+// WallClock is the date and time of a date-string as written, without
+// any location.
+type WallClock struct {
+	Year       int
+	Month      time.Month
+	Day        int
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// ParseFields parse an unknown date format returning its wall clock
+// fields as written, so time.Local plays no part, and whether the
+// date-string had an offset or zone.  The fields of an epoch timestamp
+// are UTC, and it counts as having a zone.
+//
+//     wc, zoned, err := dateparse.ParseFields("2014-04-26 17:24:37 -0700")
+//     // wc.Hour == 17, zoned == true
+//
+func ParseFields(datestr string, opts ...ParserOption) (WallClock, bool, error) {
+	p, err := parseTime(datestr, nil, opts...)
+	if err != nil {
+		return WallClock{}, false, err
+	}
+	t, err := p.parse()
+	if err != nil {
+		return WallClock{}, false, err
+	}
+	c := p.components(datestr, t, opts)
+	return c.WallClock, c.Zoned, nil
+}
+
+// Components are the fields of a date-string as written, see ParseFields.
+type Components struct {
+	WallClock
+	// Zoned is true when the date-string had an offset or zone
+	Zoned bool
+}
+
+// components are the fields of datestr parsed to t.
+func (p *parser) components(datestr string, t time.Time, opts []ParserOption) Components {
+	if p.t != nil && p.stateDate == dateDigit {
+		// 1332151919
+		t = t.UTC()
+	}
+	// the date-string has a zone when an offset, a zone name or a Z was
+	// lexed, a time not lexed when parsing it in a location does not
+	// change it
+	zoned := p.offseti > 0 || p.tzi > 0 || p.stateTime == timeZ
+	if p.t != nil {
+		if pz, err := parseTime(datestr, zoneProbe, opts...); err == nil {
+			if tz, err := pz.parse(); err == nil {
+				zoned = tz.Equal(t)
 			}
-		case dateDigitDotDot:
-			// iterate all the way through
-		case dateAlpha:
-			// dateAlphaWS
-			//  Mon Jan _2 15:04:05 2006
-			//  Mon Jan _2 15:04:05 MST 2006
-			//  Mon Jan 02 15:04:05 -0700 2006
-			//  Mon Aug 10 15:44:11 UTC+0100 2015
-			//  Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
-			//  dateAlphaWSDigit
-			//    May 8, 2009 5:57:51 PM
-			//    oct 1, 1970
-			//  dateAlphaWsMonth
-			//    April 8, 2009
-			//  dateAlphaWsMore
-			//    dateAlphaWsAtTime
-			//      January 02, 2006 at 3:04pm MST-07
-			//
-			//  dateAlphaPeriodWsDigit
-			//    oct. 1, 1970
-			// dateWeekdayComma
-			//   Monday, 02 Jan 2006 15:04:05 MST
-			//   Monday, 02-Jan-06 15:04:05 MST
-			//   Monday, 02 Jan 2006 15:04:05 -0700
-			//   Monday, 02 Jan 2006 15:04:05 +0100
-			// dateWeekdayAbbrevComma
-			//   Mon, 02 Jan 2006 15:04:05 MST
-			//   Mon, 02 Jan 2006 15:04:05 -0700
-			//   Thu, 13 Jul 2017 08:58:40 +0100
-			//   Tue, 11 Jul 2017 16:28:13 +0200 (CEST)
-			//   Mon, 02-Jan-06 15:04:05 MST
-			switch {
-			case r == ' ':
-				//      X
-				// April 8, 2009
-				if i > 3 {
-					// Check to see if the alpha is name of month?  or Day?
-					month := strings.ToLower(datestr[0:i])
-					if isMonthFull(month) {
-						p.fullMonth = month
-						// len(" 31, 2018")   = 9
-						if len(datestr[i:]) < 10 {
-							// April 8, 2009
-							p.stateDate = dateAlphaWsMonth
-						} else {
-							p.stateDate = dateAlphaWsMore
-						}
-						p.dayi = i + 1
-						break
-					}
+		}
+	}
+	return Components{
+		WallClock: WallClock{
+			Year:       t.Year(),
+			Month:      t.Month(),
+			Day:        t.Day(),
+			Hour:       t.Hour(),
+			Minute:     t.Minute(),
+			Second:     t.Second(),
+			Nanosecond: t.Nanosecond(),
+		},
+		Zoned: zoned,
+	}
+}
 
-				} else {
-					// This is possibly ambiguous?  May will parse as either though.
-					// So, it could return in-correct format.
-					// May 05, 2005, 05:05:05
-					// May 05 2005, 05:05:05
-					// Jul 05, 2005, 05:05:05
-					p.stateDate = dateAlphaWs
-				}
+// Result is everything known of a parsed date-string, for audit logs
+// keeping the input next to the time it was read as.
+type Result struct {
+	// Time is the parsed time, as ParseAny
+	Time time.Time
+	// Input is the date-string as given
+	Input string
+	// Layout is the detected layout, as ParseFormat
+	Layout string
+	// Components are the fields as written, as ParseFields
+	Components Components
+}
 
-			case r == ',':
-				// Mon, 02 Jan 2006
-				// p.moi = 0
-				// p.molen = i
-				if i == 3 {
-					p.stateDate = dateWeekdayAbbrevComma
-					p.set(0, "Mon")
-				} else {
-					p.stateDate = dateWeekdayComma
-					p.skip = i + 2
-					i++
-					// TODO:  lets just make this "skip" as we don't need
-					// the mon, monday, they are all superfelous and not needed
-					// just lay down the skip, no need to fill and then skip
-				}
-			case r == '.':
-				// sept. 28, 2017
-				// jan. 28, 2017
-				p.stateDate = dateAlphaPeriodWsDigit
-				if i == 3 {
-					p.molen = i
-					p.set(0, "Jan")
-				} else if i == 4 {
-					// gross
-					datestr = datestr[0:i-1] + datestr[i:]
-					return parseTime(datestr, loc)
-				} else {
-					return nil, unknownErr(datestr)
+// ParseResult parse an unknown date format returning the time along with
+// the input, its layout and its fields.
+//
+//     r, err := dateparse.ParseResult("2014-04-26 17:24:37 -0700")
+//     // r.Layout == "2006-01-02 15:04:05 -0700", r.Components.Zoned == true
+//
+func ParseResult(datestr string, opts ...ParserOption) (Result, error) {
+	p, err := parseTime(datestr, nil, opts...)
+	if err != nil {
+		return Result{}, err
+	}
+	t, err := p.parse()
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{
+		Time:       t,
+		Input:      datestr,
+		Layout:     p.layout(),
+		Components: p.components(datestr, t, opts),
+	}, nil
+}
+
+// ParseSlice parse a column of date-strings, typically all of the same
+// format.  The layout detected for a row is tried first on the following
+// rows of the same length, only when that fails is the format of a row
+// detected again.  The errors are per row, nil for a parsed row.
+func ParseSlice(inputs []string, opts ...ParserOption) ([]time.Time, []error) {
+	times, _, errs := ParseSliceFormats(inputs, opts...)
+	return times, errs
+}
+
+// ParseSliceFormats is ParseSlice also returning the layout each row was
+// parsed with, to surface format drift in a column.  Rows parsed with the
+// layout of a previous row report that layout, rows that failed to
+// parse an empty one.
+func ParseSliceFormats(inputs []string, opts ...ParserOption) ([]time.Time, []string, []error) {
+	times := make([]time.Time, len(inputs))
+	layouts := make([]string, len(inputs))
+	errs := make([]error, len(inputs))
+	if p, err := newParser("", nil, opts...); err == nil && p.columnInference {
+		opts = inferOrder(inputs, opts)
+	}
+	var cached *parser
+	for i, datestr := range inputs {
+		if cached != nil && len(datestr) == len(cached.datestr) {
+			p, err := newParser(datestr, nil, opts...)
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			if p.sentinel(datestr) == nil {
+				after := p.after
+				*p = *cached
+				p.datestr = datestr
+				p.format = append([]byte(nil), cached.format...)
+				p.after = after
+				if t, err := p.parse(); err == nil {
+					times[i], layouts[i] = t, p.layout()
+					continue
 				}
 			}
+		}
+		p, err := parseTime(datestr, nil, opts...)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		// the state before parse, which trims the layout
+		detected := *p
+		detected.format = append([]byte(nil), p.format...)
+		if times[i], errs[i] = p.parse(); errs[i] != nil {
+			continue
+		}
+		layouts[i] = p.layout()
+		if p.t == nil && detected.datestr == datestr {
+			cached = &detected
+		}
+	}
+	return times, layouts, errs
+}
 
-		case dateAlphaWs:
-			// dateAlphaWsAlpha
-			//   Mon Jan _2 15:04:05 2006
-			//   Mon Jan _2 15:04:05 MST 2006
-			//   Mon Jan 02 15:04:05 -0700 2006
-			//   Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
-			//   Mon Aug 10 15:44:11 UTC+0100 2015
-			//  dateAlphaWsDigit
-			//    May 8, 2009 5:57:51 PM
-			//    May 8 2009 5:57:51 PM
-			//    oct 1, 1970
-			//    oct 7, '70
-			switch {
-			case unicode.IsLetter(r):
-				p.set(0, "Mon")
-				p.stateDate = dateAlphaWsAlpha
-				p.set(i, "Jan")
-			case unicode.IsDigit(r):
-				p.set(0, "Jan")
-				p.stateDate = dateAlphaWsDigit
-				p.dayi = i
-			}
+// inferOrder adds the field order proven by the rows of a column, those
+// parsing only month first or only day first, to opts.
+func inferOrder(inputs []string, opts []ParserOption) []ParserOption {
+	var mdy, dmy bool
+	for _, datestr := range inputs {
+		_, errMDY := ParseAny(datestr, append(opts[:len(opts):len(opts)], fieldOrder(OrderMDY))...)
+		_, errDMY := ParseAny(datestr, append(opts[:len(opts):len(opts)], fieldOrder(OrderDMY))...)
+		if errMDY == nil && errDMY != nil {
+			mdy = true
+		} else if errDMY == nil && errMDY != nil {
+			dmy = true
+		}
+	}
+	switch {
+	case dmy && !mdy:
+		return append(opts[:len(opts):len(opts)], fieldOrder(OrderDMY))
+	case mdy && !dmy:
+		return append(opts[:len(opts):len(opts)], fieldOrder(OrderMDY))
+	}
+	return opts
+}
 
-		case dateAlphaWsDigit:
-			// May 8, 2009 5:57:51 PM
-			// May 8 2009 5:57:51 PM
-			// oct 1, 1970
-			// oct 7, '70
-			// oct. 7, 1970
-			if r == ',' {
-				p.daylen = i - p.dayi
-				p.setDay()
-				p.stateDate = dateAlphaWsDigitMore
-			} else if r == ' ' {
-				p.daylen = i - p.dayi
-				p.setDay()
-				p.yeari = i + 1
-				p.stateDate = dateAlphaWsDigitMoreWs
-			} else if unicode.IsLetter(r) {
-				p.stateDate = dateAlphaWsMonthSuffix
-				i--
-			}
-		case dateAlphaWsDigitMore:
-			//       x
-			// May 8, 2009 5:57:51 PM
-			// May 05, 2005, 05:05:05
-			// May 05 2005, 05:05:05
-			// oct 1, 1970
-			// oct 7, '70
-			if r == ' ' {
-				p.yeari = i + 1
-				p.stateDate = dateAlphaWsDigitMoreWs
-			}
-		case dateAlphaWsDigitMoreWs:
-			//            x
-			// May 8, 2009 5:57:51 PM
-			// May 05, 2005, 05:05:05
-			// oct 1, 1970
-			// oct 7, '70
-			switch r {
-			case '\'':
-				p.yeari = i + 1
-			case ' ', ',':
-				//            x
-				// May 8, 2009 5:57:51 PM
-				//            x
-				// May 8, 2009, 5:57:51 PM
-				p.stateDate = dateAlphaWsDigitMoreWsYear
-				p.yearlen = i - p.yeari
-				p.setYear()
-				p.stateTime = timeStart
-				break iterRunes
-			}
+// ParseStrict parse an unknown date format.  IF the date is ambigous
+// mm/dd vs dd/mm then return an error. These return errors:   3.3.2014 , 8/8/71 etc
+func ParseStrict(datestr string, opts ...ParserOption) (time.Time, error) {
+	p, err := parseTime(datestr, nil, opts...)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if p.ambiguousMD {
+		return time.Time{}, ErrAmbiguousMMDD
+	}
+	return p.parse()
+}
 
-		case dateAlphaWsAlpha:
-			// Mon Jan _2 15:04:05 2006
-			// Mon Jan 02 15:04:05 -0700 2006
-			// Mon Jan _2 15:04:05 MST 2006
-			// Mon Aug 10 15:44:11 UTC+0100 2015
-			// Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
-			if r == ' ' {
-				if p.dayi > 0 {
-					p.daylen = i - p.dayi
-					p.setDay()
-					p.yeari = i + 1
-					p.stateDate = dateAlphaWsAlphaYearmaybe
-					p.stateTime = timeStart
-				}
-			} else if unicode.IsDigit(r) {
-				if p.dayi == 0 {
-					p.dayi = i
-				}
-			}
+// ParseOrder parse an unknown date format, resolving ambiguous all-numeric
+// dates (3/1/2014, 08.21.71) with the given field order instead of the
+// default month-first heuristic.  Dates whose layout is unambiguous, such
+// as 2014/03/01 or 1 March 2014, ignore the order.
+//
+//     t, err := dateparse.ParseOrder("02/01/2014", dateparse.OrderDMY)
+//     // t = 2014-01-02
+//
+func ParseOrder(datestr string, order FieldOrder, opts ...ParserOption) (time.Time, error) {
+	opts = append(opts, fieldOrder(order))
+	p, err := parseTime(datestr, nil, opts...)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return p.parse()
+}
 
-		case dateAlphaWsAlphaYearmaybe:
-			//            x
-			// Mon Jan _2 15:04:05 2006
-			// Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
-			if r == ':' {
-				i = i - 3
-				p.stateDate = dateAlphaWsAlpha
-				p.yeari = 0
-				break iterRunes
-			} else if r == ' ' {
-				// must be year format, not 15:04
-				p.yearlen = i - p.yeari
-				p.setYear()
-				break iterRunes
-			}
+// ParseToUnix parse an unknown date format and return the seconds
+// since the unix epoch.
+func ParseToUnix(datestr string, opts ...ParserOption) (int64, error) {
+	t, err := ParseAny(datestr, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return t.Unix(), nil
+}
 
-		case dateAlphaWsMonth:
-			// April 8, 2009
-			// April 8 2009
-			switch r {
-			case ' ', ',':
-				//       x
-				// June 8, 2009
-				//       x
-				// June 8 2009
-				if p.daylen == 0 {
-					p.daylen = i - p.dayi
-					p.setDay()
-				}
-			case 's', 'S', 'r', 'R', 't', 'T', 'n', 'N':
-				// st, rd, nd, st
-				i--
-				p.stateDate = dateAlphaWsMonthSuffix
-			default:
-				if p.daylen > 0 && p.yeari == 0 {
-					p.yeari = i
-				}
-			}
-		case dateAlphaWsMonthMore:
-			//                  X
-			// January 02, 2006, 15:04:05
-			// January 02 2006, 15:04:05
-			// January 02, 2006 15:04:05
-			// January 02 2006 15:04:05
-			switch r {
-			case ',':
-				p.yearlen = i - p.yeari
-				p.setYear()
-				p.stateTime = timeStart
-				i++
-				break iterRunes
-			case ' ':
-				p.yearlen = i - p.yeari
-				p.setYear()
-				p.stateTime = timeStart
-				break iterRunes
-			}
-		case dateAlphaWsMonthSuffix:
-			//        x
-			// April 8th, 2009
-			// April 8th 2009
-			switch r {
-			case 't', 'T':
-				if p.nextIs(i, 'h') || p.nextIs(i, 'H') {
-					if len(datestr) > i+2 {
-						return parseTime(fmt.Sprintf("%s%s", p.datestr[0:i], p.datestr[i+2:]), loc)
-					}
-				}
-			case 'n', 'N':
-				if p.nextIs(i, 'd') || p.nextIs(i, 'D') {
-					if len(datestr) > i+2 {
-						return parseTime(fmt.Sprintf("%s%s", p.datestr[0:i], p.datestr[i+2:]), loc)
-					}
-				}
-			case 's', 'S':
-				if p.nextIs(i, 't') || p.nextIs(i, 'T') {
-					if len(datestr) > i+2 {
-						return parseTime(fmt.Sprintf("%s%s", p.datestr[0:i], p.datestr[i+2:]), loc)
-					}
-				}
-			case 'r', 'R':
-				if p.nextIs(i, 'd') || p.nextIs(i, 'D') {
-					if len(datestr) > i+2 {
-						return parseTime(fmt.Sprintf("%s%s", p.datestr[0:i], p.datestr[i+2:]), loc)
-					}
-				}
-			}
-		case dateAlphaWsMore:
-			// January 02, 2006, 15:04:05
-			// January 02 2006, 15:04:05
-			// January 2nd, 2006, 15:04:05
-			// January 2nd 2006, 15:04:05
-			// September 17, 2012 at 5:00pm UTC-05
-			switch {
-			case r == ',':
-				//           x
-				// January 02, 2006, 15:04:05
-				if p.nextIs(i, ' ') {
-					p.daylen = i - p.dayi
-					p.setDay()
-					p.yeari = i + 2
-					p.stateDate = dateAlphaWsMonthMore
-					i++
-				}
+// ParseToUnixMilli parse an unknown date format and return the
+// milliseconds since the unix epoch.
+//
+//     ms, err := dateparse.ParseToUnixMilli("2013-11-12 00:32:47.111")
+//     // ms = 1384216367111
+//
+func ParseToUnixMilli(datestr string, opts ...ParserOption) (int64, error) {
+	t, err := ParseAny(datestr, opts...)
+	if err != nil {
+		return 0, err
+	}
+	// not UnixNano, which overflows outside of the years 1678 to 2262
+	return t.Unix()*1e3 + int64(t.Nanosecond())/1e6, nil
+}
 
-			case r == ' ':
-				//           x
-				// January 02 2006, 15:04:05
-				p.daylen = i - p.dayi
-				p.setDay()
-				p.yeari = i + 1
-				p.stateDate = dateAlphaWsMonthMore
-			case unicode.IsDigit(r):
-				//         XX
-				// January 02, 2006, 15:04:05
-				continue
-			case unicode.IsLetter(r):
-				//          X
-				// January 2nd, 2006, 15:04:05
-				p.daylen = i - p.dayi
-				p.setDay()
-				p.stateDate = dateAlphaWsMonthSuffix
-				i--
-			}
+// ParseToUnixNano parse an unknown date format and return the
+// nanoseconds since the unix epoch.  Dates outside of the years 1678 to
+// 2262, which do not fit in an int64 of nanoseconds, are an error.
+func ParseToUnixNano(datestr string, opts ...ParserOption) (int64, error) {
+	t, err := ParseAny(datestr, opts...)
+	if err != nil {
+		return 0, err
+	}
+	if t.Before(time.Unix(0, math.MinInt64)) || t.After(time.Unix(0, math.MaxInt64)) {
+		return 0, fmt.Errorf("Date %q out of the range of unix nanoseconds", datestr)
+	}
+	return t.UnixNano(), nil
+}
 
-		case dateAlphaPeriodWsDigit:
-			//    oct. 7, '70
-			switch {
-			case r == ' ':
-				// continue
-			case unicode.IsDigit(r):
-				p.stateDate = dateAlphaWsDigit
-				p.dayi = i
-			default:
-				return p, unknownErr(datestr)
-			}
-		case dateWeekdayComma:
-			// Monday, 02 Jan 2006 15:04:05 MST
-			// Monday, 02 Jan 2006 15:04:05 -0700
-			// Monday, 02 Jan 2006 15:04:05 +0100
-			// Monday, 02-Jan-06 15:04:05 MST
-			if p.dayi == 0 {
-				p.dayi = i
-			}
-			switch r {
-			case ' ', '-':
-				if p.moi == 0 {
-					p.moi = i + 1
-					p.daylen = i - p.dayi
-					p.setDay()
-				} else if p.yeari == 0 {
-					p.yeari = i + 1
-					p.molen = i - p.moi
-					p.set(p.moi, "Jan")
-				} else {
-					p.stateTime = timeStart
-					break iterRunes
-				}
-			}
-		case dateWeekdayAbbrevComma:
-			// Mon, 02 Jan 2006 15:04:05 MST
-			// Mon, 02 Jan 2006 15:04:05 -0700
-			// Thu, 13 Jul 2017 08:58:40 +0100
-			// Thu, 4 Jan 2018 17:53:36 +0000
-			// Tue, 11 Jul 2017 16:28:13 +0200 (CEST)
-			// Mon, 02-Jan-06 15:04:05 MST
-			switch r {
-			case ' ', '-':
-				if p.dayi == 0 {
-					p.dayi = i + 1
-				} else if p.moi == 0 {
-					p.daylen = i - p.dayi
-					p.setDay()
-					p.moi = i + 1
-				} else if p.yeari == 0 {
-					p.molen = i - p.moi
-					p.set(p.moi, "Jan")
-					p.yeari = i + 1
-				} else {
-					p.yearlen = i - p.yeari
-					p.setYear()
-					p.stateTime = timeStart
-					break iterRunes
-				}
+// ParseAge parse an unknown date format and return the duration from it
+// to the WithBaseDate date, default now.  A date in the future is a
+// negative duration.
+//
+//     age, err := dateparse.ParseAge("2014-04-26 17:24:37")
+//
+func ParseAge(datestr string, opts ...ParserOption) (time.Duration, error) {
+	p, err := parseTime(datestr, nil, opts...)
+	if err != nil {
+		return 0, err
+	}
+	t, err := p.parse()
+	if err != nil {
+		return 0, err
+	}
+	return p.now().Sub(t), nil
+}
+
+// ParseElapsed parse an elapsed time [+-]HH:MM:SS[.fff] such as the
+// +01:23:45 of a stopwatch into a duration.  The hours may exceed 24,
+// +100:00:00, and the seconds have up to 9 fractional digits.
+func ParseElapsed(s string) (time.Duration, error) {
+	invalid := fmt.Errorf("Invalid elapsed time %q", s)
+	rest := s
+	sign := time.Duration(1)
+	if rest != "" && (rest[0] == '+' || rest[0] == '-') {
+		if rest[0] == '-' {
+			sign = -1
+		}
+		rest = rest[1:]
+	}
+	var frac string
+	if i := strings.IndexByte(rest, '.'); i >= 0 {
+		rest, frac = rest[:i], rest[i+1:]
+		if len(frac) < 1 || len(frac) > 9 || !allDigits(frac) {
+			return 0, invalid
+		}
+	}
+	fields := strings.Split(rest, ":")
+	if len(fields) != 3 || !allDigits(fields[0]) || len(fields[1]) != 2 || !allDigits(fields[1]) || len(fields[2]) != 2 || !allDigits(fields[2]) {
+		return 0, invalid
+	}
+	hours, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || hours > int64(math.MaxInt64/time.Hour)-1 {
+		return 0, invalid
+	}
+	mins, _ := strconv.Atoi(fields[1])
+	secs, _ := strconv.Atoi(fields[2])
+	if mins > 59 || secs > 59 {
+		return 0, invalid
+	}
+	var nsecs int
+	if frac != "" {
+		nsecs, _ = strconv.Atoi(frac + strings.Repeat("0", 9-len(frac)))
+	}
+	d := time.Duration(hours)*time.Hour + time.Duration(mins)*time.Minute +
+		time.Duration(secs)*time.Second + time.Duration(nsecs)
+	return sign * d, nil
+}
+
+// ParseBeats parse a Swatch Internet Time @248 into the time of day on
+// the date of baseDate.  The 1000 beats of 86.4 seconds each count from
+// midnight Biel Mean Time, UTC+1, and the time is in the location of
+// baseDate.
+//
+//     t, err := dateparse.ParseBeats("@500", time.Date(2014, 4, 26, 0, 0, 0, 0, time.UTC))
+//     // t = 2014-04-26 11:00:00 +0000 UTC
+//
+func ParseBeats(s string, baseDate time.Time) (time.Time, error) {
+	if len(s) != 4 || s[0] != '@' || !allDigits(s[1:]) {
+		return time.Time{}, fmt.Errorf("Invalid beats %q, want @000 to @999", s)
+	}
+	beats, _ := strconv.Atoi(s[1:])
+	bmt := time.FixedZone("BMT", 60*60)
+	midnight := time.Date(baseDate.Year(), baseDate.Month(), baseDate.Day(), 0, 0, 0, 0, bmt)
+	t := midnight.Add(time.Duration(beats) * 86400 * time.Millisecond)
+	return t.In(baseDate.Location()), nil
+}
+
+// fileTimeEpoch is the unix epoch in the 100 nanosecond ticks of a
+// windows FILETIME, counted from 1601-01-01 UTC.
+const fileTimeEpoch = 116444736000000000
+
+// ParseFileTime parse a windows FILETIME, the 100 nanosecond ticks since
+// 1601-01-01 UTC, in decimal, 130430066771234567, or in the 16 hex digits
+// of event exports, 01CF617465EE0F07 or 0x01CF617465EE0F07.  It is not
+// tried by ParseAny as the integers overlap the epoch timestamps.
+//
+//     t, err := dateparse.ParseFileTime("01CF617465EE0F07")
+//     // t = 2014-04-26 17:24:37.1234567 +0000 UTC
+//
+func ParseFileTime(s string) (time.Time, error) {
+	digits, base := s, 10
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		digits, base = s[2:], 16
+	} else if len(s) == 16 {
+		// 16 decimal digits would be before 1633
+		base = 16
+	}
+	if digits == "" || digits[0] == '+' || digits[0] == '-' {
+		return time.Time{}, fmt.Errorf("Invalid FILETIME %q", s)
+	}
+	ticks, err := strconv.ParseInt(digits, base, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid FILETIME %q, out of range or not an integer", s)
+	}
+	ticks -= fileTimeEpoch
+	secs, rem := ticks/1e7, ticks%1e7
+	if rem < 0 {
+		secs, rem = secs-1, rem+1e7
+	}
+	return time.Unix(secs, rem*100).UTC(), nil
+}
+
+// ParseRangeExpr parse a span of months sharing a year, Jan-Mar 2014 or
+// Jan–Mar 2014 with an en dash, returning midnight of the first day of
+// the first month and the exclusive end, midnight of the first day after
+// the last month.  The times are in the WithLocation location, default
+// UTC.
+//
+//     start, end, err := dateparse.ParseRangeExpr("Jan-Mar 2014")
+//     // start = 2014-01-01, end = 2014-04-01
+//
+func ParseRangeExpr(expr string, opts ...ParserOption) (time.Time, time.Time, error) {
+	p, err := newParser(expr, nil, opts...)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	invalid := fmt.Errorf("Invalid range %q, want Jan-Mar 2014", expr)
+	s := strings.Replace(p.translate(expr), "–", "-", 1)
+	dash := strings.IndexByte(s, '-')
+	if dash < 0 {
+		return time.Time{}, time.Time{}, invalid
+	}
+	rest := strings.Fields(s[dash+1:])
+	if len(rest) != 2 || len(rest[1]) != 4 || !allDigits(rest[1]) {
+		return time.Time{}, time.Time{}, invalid
+	}
+	first, ok := monthName(strings.TrimSuffix(strings.TrimSpace(s[:dash]), "."))
+	if !ok {
+		return time.Time{}, time.Time{}, invalid
+	}
+	last, ok := monthName(strings.TrimSuffix(rest[0], "."))
+	if !ok {
+		return time.Time{}, time.Time{}, invalid
+	}
+	if last < first {
+		return time.Time{}, time.Time{}, fmt.Errorf("Range %q ends before it starts", expr)
+	}
+	year, _ := strconv.Atoi(rest[1])
+	loc := p.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	start := time.Date(year, first, 1, 0, 0, 0, 0, loc)
+	return start, time.Date(year, last+1, 1, 0, 0, 0, 0, loc), nil
+}
+
+// ParseFiscalQuarter parse a fiscal quarter, Q2 FY24 or Q2 FY2024, of a
+// fiscal year starting in fiscalYearStart, returning midnight UTC of the
+// first day of the quarter and the exclusive end, midnight of the first
+// day after it.  A fiscal year is named for the calendar year it ends in,
+// so with a July start FY24 is July 2023 to June 2024.  Two digit years
+// are 1969 to 2068.
+//
+//     start, end, err := dateparse.ParseFiscalQuarter("Q2 FY24", time.July)
+//     // start = 2023-10-01, end = 2024-01-01
+//
+func ParseFiscalQuarter(s string, fiscalYearStart time.Month) (start, end time.Time, err error) {
+	if fiscalYearStart < time.January || fiscalYearStart > time.December {
+		return start, end, fmt.Errorf("Invalid fiscal year start month %d", fiscalYearStart)
+	}
+	invalid := fmt.Errorf("Invalid fiscal quarter %q, want Q1 to Q4 and FY24", s)
+	fields := strings.Fields(strings.ToUpper(s))
+	if len(fields) != 2 || len(fields[0]) != 2 || fields[0][0] != 'Q' || !strings.HasPrefix(fields[1], "FY") {
+		return start, end, invalid
+	}
+	quarter := int(fields[0][1] - '0')
+	if quarter < 1 || quarter > 4 {
+		return start, end, invalid
+	}
+	fy := fields[1][2:]
+	if (len(fy) != 2 && len(fy) != 4) || !allDigits(fy) {
+		return start, end, invalid
+	}
+	year, _ := strconv.Atoi(fy)
+	if len(fy) == 2 {
+		// as the 06 of time.Parse
+		year += 2000
+		if year >= 2069 {
+			year -= 100
+		}
+	}
+	if fiscalYearStart != time.January {
+		// the fiscal year began in the calendar year before it ends
+		year--
+	}
+	start = time.Date(year, fiscalYearStart+time.Month(3*(quarter-1)), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 3, 0), nil
+}
+
+// Normalize parse an unknown date format and format it as RFC3339 with
+// as many fractional second digits as needed, trailing zeros trimmed.
+//
+//     s, err := dateparse.Normalize("2012-08-03 18:31:59.257000000 +0000 UTC")
+//     // s = "2012-08-03T18:31:59.257Z"
+//
+func Normalize(datestr string, opts ...ParserOption) (string, error) {
+	p, err := parseTime(datestr, nil, opts...)
+	if err != nil {
+		return "", err
+	}
+	t, err := p.parse()
+	if err != nil {
+		return "", err
+	}
+	layout := time.RFC3339Nano
+	if p.keepFractionDigits && p.mslen > 0 && p.mslen <= 9 {
+		layout = "2006-01-02T15:04:05." + strings.Repeat("0", p.mslen) + "Z07:00"
+	}
+	return t.Format(layout), nil
+}
+
+// ParseFirst parse each of the date strings with ParseAny and return the
+// earliest.  Inputs that fail to parse are ignored, an error is only
+// returned when none of them parse.
+func ParseFirst(inputs ...string) (time.Time, error) {
+	return parseBest(inputs, time.Time.Before)
+}
+
+// ParseLast parse each of the date strings with ParseAny and return the
+// latest.  Inputs that fail to parse are ignored, an error is only
+// returned when none of them parse.
+func ParseLast(inputs ...string) (time.Time, error) {
+	return parseBest(inputs, time.Time.After)
+}
+
+// parseBest returns the parsed input t for which better(t, u) holds
+// against every other parsed input u.
+func parseBest(inputs []string, better func(t, u time.Time) bool) (time.Time, error) {
+	var best time.Time
+	found := false
+	for _, datestr := range inputs {
+		t, err := ParseAny(datestr)
+		if err != nil {
+			continue
+		}
+		if !found || better(t, best) {
+			best = t
+			found = true
+		}
+	}
+	if !found {
+		return time.Time{}, fmt.Errorf("Could not parse any of %q", inputs)
+	}
+	return best, nil
+}
+
+// ParseISO parse a strict ISO 8601 date-time.  Accepted are calendar
+// 2014-04-26, week 2014-W17-6 and ordinal 2014-116 dates in extended or
+// basic (20140426) format, optionally followed by a T and a time hh,
+// hh:mm or hh:mm:ss, the last of them with 1 to 9 fractional digits after
+// a . or , as 17:24,5 for 17:24:30, and an offset Z, ±hh, ±hhmm or
+// ±hh:mm.  The reduced dates 2014-04, 2014-W17 and 2014 are accepted
+// without a time.  Anything else, such as a space instead of the T or a
+// zone name, is an error.  A time without an offset is UTC.
+func ParseISO(datestr string) (time.Time, error) {
+	invalid := fmt.Errorf("Invalid ISO 8601 date %q", datestr)
+	datePart, timePart := datestr, ""
+	if i := strings.IndexByte(datestr, 'T'); i >= 0 {
+		datePart, timePart = datestr[:i], datestr[i+1:]
+		if timePart == "" {
+			return time.Time{}, invalid
+		}
+	}
+	year, month, day, extended, complete, ok := isoDate(datePart)
+	if !ok || (!complete && timePart != "") {
+		return time.Time{}, invalid
+	}
+	if timePart == "" {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+	}
+	hour, min, sec, nsec, loc, ok := isoTime(timePart, extended)
+	if !ok {
+		return time.Time{}, invalid
+	}
+	return time.Date(year, month, day, hour, min, sec, nsec, loc), nil
+}
+
+// ParseSyslog parse a syslog timestamp, either the RFC 5424
+// 2003-10-11T22:14:15.003Z with up to 6 fractional digits, or the
+// RFC 3164 Oct 11 22:14:15 which has no year.  That year is the one of
+// the WithBaseDate date, default now, or the previous year when that
+// would put the timestamp more than a month after the base date, such
+// as December logs read in January.
+func ParseSyslog(s string, opts ...ParserOption) (time.Time, error) {
+	p, err := newParser(s, nil, opts...)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if s != "" && s[0] >= '0' && s[0] <= '9' {
+		// RFC 5424
+		//   2003-10-11T22:14:15.003Z
+		//   2003-08-24T05:14:15.000003-07:00
+		if i := strings.IndexByte(s, '.'); i >= 0 {
+			frac := s[i+1:]
+			if len(frac)-len(strings.TrimLeft(frac, "0123456789")) > 6 {
+				return time.Time{}, fmt.Errorf("Invalid syslog timestamp %q", s)
 			}
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return time.Time{}, err
+		}
+		p.t = &t
+		return p.parse()
+	}
+	// RFC 3164
+	//   Oct 11 22:14:15
+	//   Feb  5 17:32:18
+	loc := p.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	t, err := time.ParseInLocation("Jan _2 15:04:05", s, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	year := yearOf(p.now(), t.Month(), t.Day(), loc)
+	day := t.Day()
+	t = time.Date(year, t.Month(), day, t.Hour(), t.Minute(), t.Second(), 0, loc)
+	if t.Day() != day {
+		return time.Time{}, fmt.Errorf("Invalid syslog timestamp %q in %d", s, year)
+	}
+	p.t = &t
+	return p.parse()
+}
+
+// yearOf is the year of a month and day written without one, the year of
+// base or the previous year when that would put the date more than a
+// month after base, such as December logs read in January.
+func yearOf(base time.Time, month time.Month, day int, loc *time.Location) int {
+	year := base.Year()
+	if time.Date(year, month, day, 0, 0, 0, 0, loc).After(base.AddDate(0, 1, 0)) {
+		year--
+	}
+	return year
+}
 
+// pastYearOf is the year of a month and day written without one that
+// is the most recent date on or before the day of base.
+func pastYearOf(base time.Time, month time.Month, day int, loc *time.Location) int {
+	base = base.In(loc)
+	year := base.Year()
+	if time.Date(year, month, day, 0, 0, 0, 0, loc).After(time.Date(year, base.Month(), base.Day(), 0, 0, 0, 0, loc)) {
+		year--
+	}
+	return year
+}
+
+// isoDate reads an ISO 8601 calendar, week or ordinal date, reporting
+// if it is in the extended format and if it is complete, not reduced.
+func isoDate(s string) (year int, month time.Month, day int, extended, complete, ok bool) {
+	if len(s) < 4 || !allDigits(s[:4]) {
+		return
+	}
+	year, _ = strconv.Atoi(s[:4])
+	rest := s[4:]
+	if rest == "" {
+		// 2014
+		return year, time.January, 1, false, false, true
+	}
+	if rest[0] == '-' {
+		extended = true
+		rest = rest[1:]
+	}
+	var t time.Time
+	var err error
+	switch {
+	case len(rest) > 0 && rest[0] == 'W':
+		// 2014-W17-6  2014W176  2014-W17  2014W17
+		if len(rest) < 3 || !allDigits(rest[1:3]) {
+			return
+		}
+		week, _ := strconv.Atoi(rest[1:3])
+		weekday := 1
+		switch rest = rest[3:]; {
+		case rest == "":
+		case extended && len(rest) == 2 && rest[0] == '-' && allDigits(rest[1:]):
+			weekday = int(rest[1] - '0')
+			complete = true
+		case !extended && len(rest) == 1 && allDigits(rest):
+			weekday = int(rest[0] - '0')
+			complete = true
 		default:
-			break iterRunes
+			return
 		}
+		t, err = isoWeekDate(year, week, weekday)
+	case len(rest) == 3 && allDigits(rest):
+		// 2014-116  2014116
+		yday, _ := strconv.Atoi(rest)
+		t, err = ordinalDate(year, yday)
+		complete = true
+	case extended && len(rest) == 2 && allDigits(rest):
+		// 2014-04
+		t, err = calendarDate(year, rest, "01")
+	case extended && len(rest) == 5 && rest[2] == '-' && allDigits(rest[:2]) && allDigits(rest[3:]):
+		// 2014-04-26
+		t, err = calendarDate(year, rest[:2], rest[3:])
+		complete = true
+	case !extended && len(rest) == 4 && allDigits(rest):
+		// 20140426
+		t, err = calendarDate(year, rest[:2], rest[2:])
+		complete = true
+	default:
+		return
 	}
-	p.coalesceDate(i)
-	if p.stateTime == timeStart {
-		// increment first one, since the i++ occurs at end of loop
-		if i < len(p.datestr) {
-			i++
+	if err != nil {
+		return
+	}
+	return t.Year(), t.Month(), t.Day(), extended, complete, true
+}
+
+// isoTime reads an ISO 8601 time of day and offset, extended hh:mm:ss
+// or basic hhmmss to match the format of the date.  A fraction is of the
+// last field, so the seconds returned may be more than 59 for a fraction
+// of an hour.
+func isoTime(s string, extended bool) (hour, min, sec, nsec int, loc *time.Location, ok bool) {
+	clock, offset := s, ""
+	if i := strings.IndexAny(s, "Z+-"); i >= 0 {
+		clock, offset = s[:i], s[i:]
+	}
+	frac := int64(-1)
+	if i := strings.IndexAny(clock, ".,"); i >= 0 {
+		digits := clock[i+1:]
+		if len(digits) < 1 || len(digits) > 9 || !allDigits(digits) {
+			return
 		}
-		// ensure we skip any whitespace prefix
-		for ; i < len(datestr); i++ {
-			r := rune(datestr[i])
-			if r != ' ' {
-				break
-			}
+		frac, _ = strconv.ParseInt(digits+strings.Repeat("0", 9-len(digits)), 10, 64)
+		clock = clock[:i]
+	}
+	var fields []string
+	if extended {
+		fields = strings.Split(clock, ":")
+	} else {
+		for ; len(clock) > 2; clock = clock[2:] {
+			fields = append(fields, clock[:2])
+		}
+		fields = append(fields, clock)
+	}
+	if len(fields) > 3 {
+		return
+	}
+	values := []int{0, 0, 0}
+	for i, field := range fields {
+		if len(field) != 2 || !allDigits(field) {
+			return
+		}
+		values[i], _ = strconv.Atoi(field)
+	}
+	hour, min, sec = values[0], values[1], values[2]
+	if hour == 24 && (min != 0 || sec != 0 || frac > 0) || hour > 24 || min > 59 || sec > 59 {
+		return
+	}
+	if frac > 0 {
+		// 17.5 is half an hour, 17:24.5 half a minute
+		frac *= []int64{3600, 60, 1}[len(fields)-1]
+		sec += int(frac / 1e9)
+		nsec = int(frac % 1e9)
+	}
+	switch {
+	case offset == "" || offset == "Z":
+		loc = time.UTC
+	default:
+		// +hh  +hhmm  +hh:mm
+		sign, digits := 1, offset[1:]
+		if offset[0] == '-' {
+			sign = -1
+		}
+		if len(digits) == 5 && digits[2] == ':' {
+			digits = digits[:2] + digits[3:]
+		}
+		if len(digits) == 2 {
+			digits += "00"
+		}
+		if len(digits) != 4 || !allDigits(digits) {
+			return
 		}
+		oh, _ := strconv.Atoi(digits[:2])
+		om, _ := strconv.Atoi(digits[2:])
+		if oh > 23 || om > 59 {
+			return
+		}
+		loc = time.FixedZone("", sign*(oh*3600+om*60))
+	}
+	return hour, min, sec, nsec, loc, true
+}
 
-	iterTimeRunes:
-		for ; i < len(datestr); i++ {
-			r := rune(datestr[i])
+// calendarDate is the date of the 2 digit month and day in year.
+func calendarDate(year int, month, day string) (time.Time, error) {
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+	t := time.Date(year, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+	if t.Month() != time.Month(m) || t.Day() != d {
+		return time.Time{}, fmt.Errorf("Invalid date %d-%s-%s", year, month, day)
+	}
+	return t, nil
+}
 
-			//gou.Debugf("%d %s %d iterTimeRunes  %s %s", i, string(r), p.stateTime, p.ds(), p.ts())
+// ordinalDate is the date of day yday, 1 to 365 or 366, of year.
+func ordinalDate(year, yday int) (time.Time, error) {
+	t := time.Date(year, time.January, yday, 0, 0, 0, 0, time.UTC)
+	if yday < 1 || t.Year() != year {
+		return time.Time{}, fmt.Errorf("Invalid day %d of year %d", yday, year)
+	}
+	return t, nil
+}
 
-			switch p.stateTime {
-			case timeStart:
-				// 22:43:22
-				// 22:43
-				// timeComma
-				//   08:20:13,787
-				// timeWs
-				//   05:24:37 PM
-				//   06:20:00 UTC
-				//   06:20:00 UTC-05
-				//   00:12:00 +0000 UTC
-				//   22:18:00 +0000 UTC m=+0.000000001
-				//   15:04:05 -0700
-				//   15:04:05 -07:00
-				//   15:04:05 2008
-				// timeOffset
-				//   03:21:51+00:00
-				//   19:55:00+0100
-				// timePeriod
-				//   17:24:37.3186369
-				//   00:07:31.945167
-				//   18:31:59.257000000
-				//   00:00:00.000
-				//   timePeriodOffset
-				//     19:55:00.799+0100
-				//     timePeriodOffsetColon
-				//       15:04:05.999-07:00
-				//   timePeriodWs
-				//     timePeriodWsOffset
-				//       00:07:31.945167 +0000
-				//       00:00:00.000 +0000
-				//     timePeriodWsOffsetAlpha
-				//       00:07:31.945167 +0000 UTC
-				//       22:18:00.001 +0000 UTC m=+0.000000001
-				//       00:00:00.000 +0000 UTC
-				//     timePeriodWsAlpha
-				//       06:20:00.000 UTC
-				if p.houri == 0 {
-					p.houri = i
-				}
-				switch r {
-				case ',':
-					// hm, lets just swap out comma for period.  for some reason go
-					// won't parse it.
-					// 2014-05-11 08:20:13,787
-					ds := []byte(p.datestr)
-					ds[i] = '.'
-					return parseTime(string(ds), loc)
-				case '-', '+':
-					//   03:21:51+00:00
-					p.stateTime = timeOffset
-					if p.seci == 0 {
-						// 22:18+0530
-						p.minlen = i - p.mini
-					} else {
-						p.seclen = i - p.seci
-					}
-					p.offseti = i
-				case '.':
-					p.stateTime = timePeriod
-					p.seclen = i - p.seci
-					p.msi = i + 1
-				case 'Z':
-					p.stateTime = timeZ
-					if p.seci == 0 {
-						p.minlen = i - p.mini
-					} else {
-						p.seclen = i - p.seci
-					}
-				case 'a', 'A':
-					if p.nextIs(i, 't') || p.nextIs(i, 'T') {
-						//                    x
-						// September 17, 2012 at 5:00pm UTC-05
-						i++ // skip t
-						if p.nextIs(i, ' ') {
-							//                      x
-							// September 17, 2012 at 5:00pm UTC-05
-							i++         // skip '
-							p.houri = 0 // reset hour
-						}
-					} else {
-						switch {
-						case r == 'a' && p.nextIs(i, 'm'):
-							p.coalesceTime(i)
-							p.set(i, "am")
-						case r == 'A' && p.nextIs(i, 'M'):
-							p.coalesceTime(i)
-							p.set(i, "PM")
-						}
-					}
+// isoWeekDate is the date of the ISO 8601 week, 1 to 52 or 53, and day,
+// 1 Monday to 7 Sunday, of year.  Week 1 is the week with the first
+// Thursday of the year so it may start in the previous year.
+func isoWeekDate(year, week, day int) (time.Time, error) {
+	if day < 1 || day > 7 {
+		return time.Time{}, fmt.Errorf("Invalid ISO week day %d", day)
+	}
+	// December 28th is always in the last week of the year
+	if _, last := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek(); week < 1 || week > last {
+		return time.Time{}, fmt.Errorf("Invalid ISO week %d of year %d", week, year)
+	}
+	// January 4th is always in week 1
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	return monday.AddDate(0, 0, (week-1)*7+day-1), nil
+}
 
-				case 'p', 'P':
-					// Could be AM/PM
-					switch {
-					case r == 'p' && p.nextIs(i, 'm'):
-						p.coalesceTime(i)
-						p.set(i, "pm")
-					case r == 'P' && p.nextIs(i, 'M'):
-						p.coalesceTime(i)
-						p.set(i, "PM")
-					}
-				case ' ':
-					p.coalesceTime(i)
-					p.stateTime = timeWs
-				case ':':
-					if p.mini == 0 {
-						p.mini = i + 1
-						p.hourlen = i - p.houri
-					} else if p.seci == 0 {
-						p.seci = i + 1
-						p.minlen = i - p.mini
-					}
+// weekDate is the date of day in week, 1 to 53, of year for weeks that
+// start on start.  Monday weeks follow the ISO 8601 rules, other weeks
+// are numbered from the one holding January 1st.
+func weekDate(year, week int, day, start time.Weekday) (time.Time, error) {
+	if start == time.Monday {
+		return isoWeekDate(year, week, (int(day)+6)%7+1)
+	}
+	jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	first := jan1.AddDate(0, 0, -((int(jan1.Weekday()) - int(start) + 7) % 7))
+	begin := first.AddDate(0, 0, (week-1)*7)
+	if week < 1 || week > 53 || begin.Year() > year {
+		return time.Time{}, fmt.Errorf("Invalid week %d of year %d", week, year)
+	}
+	return begin.AddDate(0, 0, (int(day)-int(start)+7)%7), nil
+}
+
+func parseTime(datestr string, loc *time.Location, opts ...ParserOption) (*parser, error) {
+
+	p, err := newParser(datestr, loc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if p.asciiOnly {
+		for i := 0; i < len(datestr); i++ {
+			if datestr[i] >= utf8.RuneSelf {
+				return nil, fmt.Errorf("Non-ASCII byte at %d in %q", i, datestr)
+			}
+		}
+	}
+	if t := p.sentinel(datestr); t != nil {
+		// the sentinel time as is, the options do not apply
+		p.t = t
+		p.after = nil
+		return p, nil
+	}
+	for _, layout := range registeredLayouts {
+		// RegisterLayout layouts before the built in formats
+		var t time.Time
+		if p.loc == nil {
+			t, err = time.Parse(layout, datestr)
+		} else {
+			t, err = time.ParseInLocation(layout, datestr, p.loc)
+		}
+		if err == nil {
+			p.format = []byte(layout)
+			p.t = &t
+			return p, nil
+		}
+	}
+	if p.noHeuristics && !unambiguous(datestr) {
+		return nil, fmt.Errorf("No registered layout for %q, heuristics are disabled", datestr)
+	}
+	if p.monthNames != nil {
+		ds, err := overrideMonthNames(datestr, p.monthNames)
+		if err != nil {
+			return nil, err
+		}
+		// the english names written in their place are not overridden
+		return parseTime(ds, loc, append(opts[:len(opts):len(opts)], WithMonthNamesOverride(nil))...)
+	}
+	if p.assumeCentury {
+		// 04/26/214 is lexed as is, its short year then written in full
+		opts = append(opts[:len(opts):len(opts)], WithAssumeCentury(false))
+		q, err := parseTime(datestr, loc, opts...)
+		if err != nil || q.t != nil || q.yearlen < 1 || q.yearlen > 3 {
+			return q, err
+		}
+		short := q.datestr[q.yeari : q.yeari+q.yearlen]
+		if !allDigits(short) {
+			return q, nil
+		}
+		year := fmt.Sprintf("%04d", centuryYear(p.now().Year(), short))
+		return parseTime(q.datestr[:q.yeari]+year+q.datestr[q.yeari+q.yearlen:], loc, opts...)
+	}
+	if p.trimPunctuation {
+		if ds := trimTrailingPunctuation(datestr); ds != datestr {
+			// April 26, 2014!
+			return parseTime(ds, loc, opts...)
+		}
+	}
+	if p.strictRFC3339 {
+		// only 2006-01-02T15:04:05Z07:00, the fraction optional
+		t, err := time.Parse(time.RFC3339, datestr)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid RFC 3339 date %q: %v", datestr, err)
+		}
+		p.format = []byte(time.RFC3339)
+		if strings.IndexByte(datestr, '.') >= 0 {
+			// 2014-04-26T17:24:37.123Z
+			p.format = []byte(time.RFC3339Nano)
+		}
+		p.t = &t
+		return p, nil
+	}
+	if ds := p.translate(datestr); ds != datestr {
+		// localized names and notation are rewritten to the english
+		// equivalents and the result parsed instead
+		if day, ok := leadingWeekday(ds); ok && p.validateWeekday {
+			// Montag, 26. April 2014
+			opts = append(opts[:len(opts):len(opts)], checkWeekday(day))
+		}
+		opts = append(opts[:len(opts):len(opts)], relayout(datestr, ds, renamedLayout))
+		return parseTime(ds, loc, opts...)
+	}
+	if ds, ok := ungroupDigits(datestr); ok {
+		// 1,384,216,367
+		if !p.flexibleEpoch {
+			return nil, fmt.Errorf("Thousands separators in %q, an epoch timestamp is not grouped, see WithFlexibleEpoch", datestr)
+		}
+		return parseTime(ds, loc, opts...)
+	}
+	if ds, ok := trimComment(datestr); ok {
+		// 04:08:03 +0100 (UTC+01:00)
+		return parseTime(ds, loc, opts...)
+	}
+	if ds, day, ok := p.trimWeekday(datestr); ok {
+		// 2014-04-26 (Sat)
+		// 2014-04-26(土)
+		// 2014/04/26(土) 17:24
+		if p.validateWeekday {
+			opts = append(opts[:len(opts):len(opts)], checkWeekday(day))
+		}
+		return parseTime(ds, loc, opts...)
+	}
+	if ds, ok, err := isoWeekFirst(datestr); err != nil {
+		return nil, err
+	} else if ok {
+		// 2014-W15-2T09:00:00Z
+		return parseTime(ds, loc, opts...)
+	}
+	if p.dotTime {
+		if ds, ok := dotTime(datestr); ok {
+			// 2014-04-26T15.30.00Z
+			return parseTime(ds, loc, opts...)
+		}
+	}
+	if n := len(datestr); p.localDesignator && n > 1 && datestr[n-1] == 'L' && unicode.IsDigit(rune(datestr[n-2])) {
+		// 2014-04-26T17:24:37L
+		return parseTime(datestr[:n-1], loc, opts...)
+	}
+	if ds, ok, err := spacePadded(datestr); err != nil {
+		return nil, err
+	} else if ok {
+		// 2014/ 4/ 8
+		if !p.spacePadding {
+			return nil, fmt.Errorf("Space padded field in %q, see WithSpacePadding", datestr)
+		}
+		opts = append(opts[:len(opts):len(opts)], relayout(datestr, ds, spacePaddedLayout))
+		return parseTime(ds, loc, opts...)
+	}
+	if p.goLayoutTokens {
+		if ds, ok := goLayoutZone(datestr); ok {
+			// 2014-04-26 15:04:05Z0700
+			return parseTime(ds, loc, opts...)
+		}
+	}
+	if p.plusSeparator {
+		if ds, ok := plusSeparator(datestr); ok {
+			// 2014-04-26+17:24:37
+			return parseTime(ds, loc, opts...)
+		}
+	}
+	if ds, ok, err := ordinalFirst(datestr); err != nil {
+		return nil, err
+	} else if ok {
+		// 2014-103T09:00:00Z
+		return parseTime(ds, loc, opts...)
+	}
+	if ds, ok := clockFirst(datestr); ok {
+		// 17:24:37, Apr 26 2014
+		opts = append(opts[:len(opts):len(opts)], relayout(datestr, ds, timeFirstLayout))
+		return parseTime(ds, loc, opts...)
+	}
+	if p.relative {
+		if ds, ok := timeFirst(datestr); ok {
+			// at 5:24 PM on April 26, 2014
+			opts = append(opts[:len(opts):len(opts)], relayout(datestr, ds, timeFirstLayout))
+			return parseTime(ds, loc, opts...)
+		}
+		if ds, end, ok := trimMonthBoundary(datestr); ok {
+			// 2014-04 EOM
+			opts = append(opts[:len(opts):len(opts)], monthBoundary(end))
+			return parseTime(ds, loc, opts...)
+		}
+		if ds, ok, err := dayOfYearFirst(datestr); err != nil {
+			return nil, err
+		} else if ok {
+			// Day 103 of 2014 14:30
+			return parseTime(ds, loc, opts...)
+		}
+		t, err := p.relativeTime(datestr)
+		if err != nil {
+			return nil, err
+		}
+		if t != nil {
+			p.t = t
+			return p, nil
+		}
+	}
+	if t, err := p.weekTime(datestr); err != nil {
+		return nil, err
+	} else if t != nil {
+		// Week 15 2014 Monday
+		p.t = t
+		return p, nil
+	}
+	if t := p.monthYearTime(datestr); t != nil {
+		// April 2014   2014 April
+		p.t = t
+		return p, nil
+	}
+	if t, err := p.monthDayTime(datestr); err != nil {
+		return nil, err
+	} else if t != nil {
+		// Apr 26 17:24
+		// 26-Apr 17:24:37
+		p.t = t
+		return p, nil
+	}
+	i := 0
+
+	// General strategy is to read rune by rune through the date looking for
+	// certain hints of what type of date we are dealing with.
+	// Hopefully we only need to read about 5 or 6 bytes before
+	// we figure it out and then attempt a parse
+iterRunes:
+	for ; i < len(datestr); i++ {
+		//r := rune(datestr[i])
+		r, bytesConsumed := utf8.DecodeRuneInString(datestr[i:])
+		if bytesConsumed > 1 {
+			i += (bytesConsumed - 1)
+		}
+
+		//gou.Debugf("i=%d r=%s state=%d   %s", i, string(r), p.stateDate, datestr)
+		switch p.stateDate {
+		case dateStart:
+			if unicode.IsDigit(r) {
+				p.stateDate = dateDigit
+			} else if unicode.IsLetter(r) {
+				p.stateDate = dateAlpha
+			} else {
+				return nil, unknownErr(datestr)
+			}
+		case dateDigit:
+
+			if (i == 12 || i == 14) && strings.ContainsRune("Zz.+-", r) {
+				// 20140426172437Z     GeneralizedTime
+				// 20140426172437.123Z
+				// 20140426172437-0500
+				// 140426172437Z       UTCTime
+				return p, p.setGeneralizedTime(i)
+			}
+			switch r {
+			case '-', '\u2212', '/', '.':
+				p.dateSep = r
+			}
+			switch r {
+			case '-', '\u2212':
+				// 2006-01-02
+				// 2013-Feb-03
+				// 13-Feb-03
+				// 29-Jun-2016
+				if i == 4 {
+					p.stateDate = dateYearDash
+					p.yeari = 0
+					p.yearlen = i
+					p.moi = i + 1
+					p.set(0, "2006")
+				} else if i > 4 {
+					// 99999999-01-01
+					return nil, unknownErr(datestr)
+				} else {
+					p.stateDate = dateDigitDash
 				}
-			case timeOffset:
-				// 19:55:00+0100
-				// timeOffsetColon
-				//   15:04:05+07:00
-				//   15:04:05-07:00
-				if r == ':' {
-					p.stateTime = timeOffsetColon
+			case '/':
+				// 03/31/2005
+				// 2014/02/24
+				p.stateDate = dateDigitSlash
+				if i == 4 {
+					p.yearlen = i
+					p.moi = i + 1
+					p.setYear()
+				} else {
+					p.ambiguousMD = true
+					p.setFirstPart(i)
 				}
-			case timeWs:
-				// timeWsAlpha
-				//   06:20:00 UTC
-				//   06:20:00 UTC-05
-				//   15:44:11 UTC+0100 2015
-				//   18:04:07 GMT+0100 (GMT Daylight Time)
-				//   17:57:51 MST 2009
-				//   timeWsAMPMMaybe
-				//     05:24:37 PM
-				// timeWsOffset
-				//   15:04:05 -0700
-				//   00:12:00 +0000 UTC
-				//   timeWsOffsetColon
-				//     15:04:05 -07:00
-				//     17:57:51 -0700 2009
-				//     timeWsOffsetColonAlpha
-				//       00:12:00 +00:00 UTC
-				// timeWsYear
-				//     00:12:00 2008
-				// timeZ
-				//   15:04:05.99Z
-				switch r {
-				case 'A', 'P':
-					// Could be AM/PM or could be PST or similar
-					p.tzi = i
-					p.stateTime = timeWsAMPMMaybe
-				case '+', '-':
-					p.offseti = i
-					p.stateTime = timeWsOffset
-				default:
-					if unicode.IsLetter(r) {
-						// 06:20:00 UTC
-						// 06:20:00 UTC-05
-						// 15:44:11 UTC+0100 2015
-						// 17:57:51 MST 2009
-						p.tzi = i
-						p.stateTime = timeWsAlpha
-						//break iterTimeRunes
-					} else if unicode.IsDigit(r) {
-						// 00:12:00 2008
-						p.stateTime = timeWsYear
-						p.yeari = i
+
+			case '.':
+				// 3.31.2014
+				// 08.21.71
+				// 2014.05
+				if t, ok := fractionalEpoch(datestr); ok {
+					// 1384216367.111222 has no date separator
+					p.dateSep = 0
+					if loc != nil {
+						t = t.In(loc)
 					}
+					p.t = &t
+					return p, nil
 				}
-			case timeWsAlpha:
-				// 06:20:00 UTC
-				// 06:20:00 UTC-05
-				// timeWsAlphaWs
-				//   17:57:51 MST 2009
-				// timeWsAlphaZoneOffset
-				// timeWsAlphaZoneOffsetWs
-				//   timeWsAlphaZoneOffsetWsExtra
-				//     18:04:07 GMT+0100 (GMT Daylight Time)
-				//   timeWsAlphaZoneOffsetWsYear
-				//     15:44:11 UTC+0100 2015
-				switch r {
-				case '+', '-':
-					p.tzlen = i - p.tzi
-					if p.tzlen == 4 {
-						p.set(p.tzi, " MST")
-					} else if p.tzlen == 3 {
-						p.set(p.tzi, "MST")
-					}
-					p.stateTime = timeWsAlphaZoneOffset
-					p.offseti = i
-				case ' ':
-					// 17:57:51 MST 2009
-					p.tzlen = i - p.tzi
-					if p.tzlen == 4 {
-						p.set(p.tzi, " MST")
-					} else if p.tzlen == 3 {
-						p.set(p.tzi, "MST")
-					}
-					p.stateTime = timeWsAlphaWs
-					p.yeari = i + 1
+				p.stateDate = dateDigitDot
+				if i == 4 {
+					p.yearlen = i
+					p.moi = i + 1
+					p.setYear()
+				} else {
+					p.ambiguousMD = true
+					p.setFirstPart(i)
 				}
-			case timeWsAlphaWs:
-				//   17:57:51 MST 2009
 
-			case timeWsAlphaZoneOffset:
-				// 06:20:00 UTC-05
-				// timeWsAlphaZoneOffset
-				// timeWsAlphaZoneOffsetWs
-				//   timeWsAlphaZoneOffsetWsExtra
-				//     18:04:07 GMT+0100 (GMT Daylight Time)
-				//   timeWsAlphaZoneOffsetWsYear
-				//     15:44:11 UTC+0100 2015
-				switch r {
-				case ' ':
-					p.set(p.offseti, "-0700")
-					p.yeari = i + 1
-					p.stateTime = timeWsAlphaZoneOffsetWs
+			case ' ':
+				// 18 January 2018
+				// 8 January 2018
+				// 8 jan 2018
+				// 02 Jan 2018 23:59
+				// 02 Jan 2018 23:59:34
+				// 12 Feb 2006, 19:17
+				// 12 Feb 2006, 19:17:22
+				p.stateDate = dateDigitWs
+				p.dayi = 0
+				p.daylen = i
+			case '年':
+				// Chinese Year
+				p.stateDate = dateDigitChineseYear
+			case ',':
+				if ds, ok := dropMonthComma(datestr, i); ok {
+					// 26, April 2014
+					opts = append(opts[:len(opts):len(opts)], relayout(datestr, ds, renamedLayout))
+					return parseTime(ds, loc, opts...)
 				}
-			case timeWsAlphaZoneOffsetWs:
-				// timeWsAlphaZoneOffsetWs
-				//   timeWsAlphaZoneOffsetWsExtra
-				//     18:04:07 GMT+0100 (GMT Daylight Time)
-				//   timeWsAlphaZoneOffsetWsYear
-				//     15:44:11 UTC+0100 2015
-				if unicode.IsDigit(r) {
-					p.stateTime = timeWsAlphaZoneOffsetWsYear
-				} else {
-					p.extra = i - 1
-					p.stateTime = timeWsAlphaZoneOffsetWsExtra
+				return nil, unknownErr(datestr)
+			default:
+				if (i == 1 || i == 2) && unicode.IsLetter(r) {
+					// 26Apr2014:17:24:37 +0100
+					p.stateDate = dateDigitAlpha
+					p.daylen = i
+					p.setDay()
+					p.moi = i
+					break
 				}
-			case timeWsAlphaZoneOffsetWsYear:
-				// 15:44:11 UTC+0100 2015
-				if unicode.IsDigit(r) {
-					p.yearlen = i - p.yeari + 1
-					if p.yearlen == 4 {
-						p.setYear()
-					}
+				continue
+			}
+			p.part1Len = i
+
+		case dateYearDash:
+			// dateYearDashDashT
+			//  2006-01-02T15:04:05Z07:00
+			// dateYearDashDashWs
+			//  2013-04-01 22:43:22
+			// dateYearDashAlphaDash
+			//   2013-Feb-03
+			switch r {
+			case '-':
+				p.molen = i - p.moi
+				p.dayi = i + 1
+				p.stateDate = dateYearDashDash
+				p.setMonth()
+			default:
+				if unicode.IsLetter(r) {
+					p.stateDate = dateYearDashAlphaDash
 				}
-			case timeWsAMPMMaybe:
-				// timeWsAMPMMaybe
-				//   timeWsAMPM
-				//     05:24:37 PM
-				//   timeWsAlpha
-				//     00:12:00 PST
-				//     15:44:11 UTC+0100 2015
-				if r == 'M' {
-					//return parse("2006-01-02 03:04:05 PM", datestr, loc)
-					p.stateTime = timeWsAMPM
-					p.set(i-1, "PM")
-					if p.hourlen == 2 {
-						p.set(p.houri, "03")
-					} else if p.hourlen == 1 {
-						p.set(p.houri, "3")
-					}
-				} else {
-					p.stateTime = timeWsAlpha
+			}
+
+		case dateYearDashDash:
+			// dateYearDashDashT
+			//  2006-01-02T15:04:05Z07:00
+			// dateYearDashDashWs
+			//  2013-04-01 22:43:22
+			switch r {
+			case ' ':
+				p.daylen = i - p.dayi
+				p.stateDate = dateYearDashDashWs
+				p.stateTime = timeStart
+				p.setDay()
+				break iterRunes
+			case 'T', 't':
+				// 2009-08-12t22:15:09z
+				p.daylen = i - p.dayi
+				p.stateDate = dateYearDashDashT
+				p.stateTime = timeStart
+				p.setDay()
+				break iterRunes
+			}
+		case dateYearDashAlphaDash:
+			// 2013-Feb-03
+			switch r {
+			case '-':
+				p.molen = i - p.moi
+				p.set(p.moi, "Jan")
+				p.dayi = i + 1
+			}
+		case dateDigitDash:
+			// 13-Feb-03
+			// 29-Jun-2016
+			// 04-26
+			// 04-26-2014
+			if unicode.IsLetter(r) {
+				p.stateDate = dateDigitDashAlpha
+				p.moi = i
+			} else if unicode.IsDigit(r) {
+				p.stateDate = dateDigitDashDigit
+				p.ambiguousMD = true
+				p.setFirstPart(p.part1Len)
+			} else {
+				return nil, unknownErr(datestr)
+			}
+		case dateDigitDashDigit:
+			// 04-26
+			// 04-26 17:24
+			// 04-26-2014
+			switch r {
+			case ' ':
+				p.stateTime = timeStart
+				if p.yeari > 0 {
+					p.yearlen = i - p.yeari
+					p.setYear()
 				}
+				break iterRunes
+			case '-':
+				p.setSecondPart(i)
+			}
+		case dateDigitDashAlpha:
+			// 13-Feb-03
+			// 28-Feb-03
+			// 29-Jun-2016
+			switch r {
+			case '-':
+				p.molen = i - p.moi
+				p.set(p.moi, "Jan")
+				p.yeari = i + 1
+				p.stateDate = dateDigitDashAlphaDash
+			}
 
-			case timeWsOffset:
-				// timeWsOffset
-				//   15:04:05 -0700
-				//   timeWsOffsetWsOffset
-				//     17:57:51 -0700 -07
-				//   timeWsOffsetWs
-				//     17:57:51 -0700 2009
-				//     00:12:00 +0000 UTC
-				//   timeWsOffsetColon
-				//     15:04:05 -07:00
-				//     timeWsOffsetColonAlpha
-				//       00:12:00 +00:00 UTC
-				switch r {
-				case ':':
-					p.stateTime = timeWsOffsetColon
-				case ' ':
-					p.set(p.offseti, "-0700")
-					p.yeari = i + 1
-					p.stateTime = timeWsOffsetWs
+		case dateDigitDashAlphaDash:
+			// 13-Feb-03   ambiguous
+			// 28-Feb-03   ambiguous
+			// 29-Jun-2016
+			switch r {
+			case ' ':
+				// we need to find if this was 4 digits, aka year
+				// or 2 digits which makes it ambiguous year/day
+				length := i - (p.moi + p.molen + 1)
+				if length == 4 {
+					p.yearlen = 4
+					p.set(p.yeari, "2006")
+					// We now also know that part1 was the day
+					p.dayi = 0
+					p.daylen = p.part1Len
+					p.setDay()
+				} else if length == 2 {
+					// We have no idea if this is
+					// yy-mon-dd   OR  dd-mon-yy
+					//
+					// We are going to ASSUME (bad, bad) that it is dd-mon-yy  which is a horible assumption
+					p.ambiguousMD = true
+					p.yearlen = 2
+					p.set(p.yeari, "06")
+					// We now also know that part1 was the day
+					p.dayi = 0
+					p.daylen = p.part1Len
+					p.setDay()
 				}
-			case timeWsOffsetWs:
-				// 17:57:51 -0700 2009
-				// 00:12:00 +0000 UTC
-				// 22:18:00.001 +0000 UTC m=+0.000000001
-				// w Extra
-				//   17:57:51 -0700 -07
-				switch r {
-				case '=':
-					// eff you golang
-					if datestr[i-1] == 'm' {
-						p.extra = i - 2
-						p.trimExtra()
-						break
-					}
-				case '+', '-':
-					// This really doesn't seem valid, but for some reason when round-tripping a go date
-					// their is an extra +03 printed out.  seems like go bug to me, but, parsing anyway.
-					// 00:00:00 +0300 +03
-					// 00:00:00 +0300 +0300
-					p.extra = i - 1
-					p.stateTime = timeWsOffset
-					p.trimExtra()
-					break
-				default:
-					switch {
-					case unicode.IsDigit(r):
-						p.yearlen = i - p.yeari + 1
-						if p.yearlen == 4 {
-							p.setYear()
-						}
-					case unicode.IsLetter(r):
-						if p.tzi == 0 {
-							p.tzi = i
-						}
-					}
+				p.stateTime = timeStart
+				break iterRunes
+			}
+
+		case dateDigitSlash:
+			// 2014/07/10 06:55:38.156283
+			// 03/19/2012 10:11:59
+			// 04/2/2014 03:00:37
+			// 3/1/2012 10:11:59
+			// 4/8/2014 22:05
+			// 3/1/2014
+			// 10/13/2014
+			// 01/02/2006
+			// 1/2/06
+
+			switch r {
+			case ' ':
+				p.stateTime = timeStart
+				if p.yeari > 0 && p.yearlen == 0 {
+					p.yearlen = i - p.yeari
+					p.setYear()
+				} else if p.yearlen > 0 && p.daylen == 0 {
+					p.daylen = i - p.dayi
+					p.setDay()
 				}
+				break iterRunes
+			case '/':
+				// 2014/07/10 06:55:38.156283
+				p.setSecondPart(i)
+			}
 
-			case timeWsOffsetColon:
-				// timeWsOffsetColon
-				//   15:04:05 -07:00
-				//   timeWsOffsetColonAlpha
-				//     2015-02-18 00:12:00 +00:00 UTC
-				if unicode.IsLetter(r) {
-					// 2015-02-18 00:12:00 +00:00 UTC
-					p.stateTime = timeWsOffsetColonAlpha
-					break iterTimeRunes
+		case dateDigitAlpha:
+			// 26Apr2014
+			// 26Apr2014:17:24:37 +0100
+			if unicode.IsDigit(r) {
+				p.molen = i - p.moi
+				if p.molen != 3 {
+					return nil, unknownErr(datestr)
 				}
-			case timePeriod:
-				// 15:04:05.999999999+07:00
-				// 15:04:05.999999999-07:00
-				// 15:04:05.999999+07:00
-				// 15:04:05.999999-07:00
-				// 15:04:05.999+07:00
-				// 15:04:05.999-07:00
-				// timePeriod
-				//   17:24:37.3186369
-				//   00:07:31.945167
-				//   18:31:59.257000000
-				//   00:00:00.000
-				//   timePeriodOffset
-				//     19:55:00.799+0100
-				//     timePeriodOffsetColon
-				//       15:04:05.999-07:00
-				//   timePeriodWs
-				//     timePeriodWsOffset
-				//       00:07:31.945167 +0000
-				//       00:00:00.000 +0000
-				//       With Extra
-				//         00:00:00.000 +0300 +03
-				//     timePeriodWsOffsetAlpha
-				//       00:07:31.945167 +0000 UTC
-				//       00:00:00.000 +0000 UTC
-				//       22:18:00.001 +0000 UTC m=+0.000000001
-				//     timePeriodWsAlpha
-				//       06:20:00.000 UTC
-				switch r {
-				case ' ':
-					p.mslen = i - p.msi
-					p.stateTime = timePeriodWs
-				case '+', '-':
-					// This really shouldn't happen
-					p.mslen = i - p.msi
-					p.offseti = i
-					p.stateTime = timePeriodOffset
-				default:
-					if unicode.IsLetter(r) {
-						// 06:20:00.000 UTC
-						p.mslen = i - p.msi
-						p.stateTime = timePeriodWsAlpha
-					}
+				p.set(p.moi, "Jan")
+				p.yeari = i
+				p.stateDate = dateDigitAlphaDigit
+			} else if !unicode.IsLetter(r) {
+				return nil, unknownErr(datestr)
+			}
+		case dateDigitAlphaDigit:
+			// 26Apr2014:17:24:37 +0100
+			switch r {
+			case ':':
+				p.yearlen = i - p.yeari
+				p.setYear()
+				p.stateTime = timeStart
+				break iterRunes
+			default:
+				if !unicode.IsDigit(r) {
+					return nil, unknownErr(datestr)
 				}
-			case timePeriodOffset:
-				// timePeriodOffset
-				//   19:55:00.799+0100
-				//   timePeriodOffsetColon
-				//     15:04:05.999-07:00
-				//     13:31:51.999-07:00 MST
-				if r == ':' {
-					p.stateTime = timePeriodOffsetColon
+			}
+		case dateDigitWs:
+			// 18 January 2018
+			// 8 January 2018
+			// 8 jan 2018
+			// 1 jan 18
+			// 02 Jan 2018 23:59
+			// 02 Jan 2018 23:59:34
+			// 12 Feb 2006, 19:17
+			// 12 Feb 2006, 19:17:22
+			switch r {
+			case ',':
+				if ds, ok := dropMonthComma(datestr, i); ok {
+					// 26 April, 2014
+					opts = append(opts[:len(opts):len(opts)], relayout(datestr, ds, renamedLayout))
+					return parseTime(ds, loc, opts...)
 				}
-			case timePeriodOffsetColon:
-				// timePeriodOffset
-				//   timePeriodOffsetColon
-				//     15:04:05.999-07:00
-				//     13:31:51.999 -07:00 MST
-				switch r {
-				case ' ':
-					p.set(p.offseti, "-07:00")
-					p.stateTime = timePeriodOffsetColonWs
-					p.tzi = i + 1
+			case ' ':
+				p.yeari = i + 1
+				//p.yearlen = 4
+				p.dayi = 0
+				p.daylen = p.part1Len
+				p.setDay()
+				p.stateTime = timeStart
+				if i > p.daylen+len(" Sep") { //  November etc
+					// If len greather than space + 3 it must be full month
+					p.stateDate = dateDigitWsMolong
+				} else {
+					// If len=3, the might be Feb or May?  Ie ambigous abbreviated but
+					// we can parse may with either.  BUT, that means the
+					// format may not be correct?
+					// mo := strings.ToLower(datestr[p.daylen+1 : i])
+					p.moi = p.daylen + 1
+					p.molen = i - p.moi
+					p.set(p.moi, "Jan")
+					p.stateDate = dateDigitWsMoYear
 				}
-			case timePeriodOffsetColonWs:
-				// continue
-			case timePeriodWs:
-				// timePeriodWs
-				//   timePeriodWsOffset
-				//     00:07:31.945167 +0000
-				//     00:00:00.000 +0000
-				//   timePeriodWsOffsetAlpha
-				//     00:07:31.945167 +0000 UTC
-				//     00:00:00.000 +0000 UTC
-				//   timePeriodWsOffsetColon
-				//     13:31:51.999 -07:00 MST
-				//   timePeriodWsAlpha
-				//     06:20:00.000 UTC
-				if p.offseti == 0 {
-					p.offseti = i
+			}
+
+		case dateDigitWsMoYear:
+			// 8 jan 2018
+			// 02 Jan 2018 23:59
+			// 02 Jan 2018 23:59:34
+			// 12 Feb 2006, 19:17
+			// 12 Feb 2006, 19:17:22
+			switch r {
+			case ',':
+				p.yearlen = i - p.yeari
+				p.setYear()
+				i++
+				break iterRunes
+			case ' ':
+				p.yearlen = i - p.yeari
+				p.setYear()
+				break iterRunes
+			}
+		case dateDigitWsMolong:
+			// 18 January 2018
+			// 8 January 2018
+
+		case dateDigitChineseYear:
+			// dateDigitChineseYear
+			//   2014年04月08日
+			//               weekday  %Y年%m月%e日 %A %I:%M %p
+			// 2013年07月18日 星期四 10:27 上午
+			if r == ' ' {
+				p.stateDate = dateDigitChineseYearWs
+				break
+			}
+		case dateDigitDot:
+			// This is the 2nd period
+			// 3.31.2014
+			// 08.21.71
+			// 2014.05
+			// 2018.09.30
+			if r == '.' {
+				// 3.31.2014
+				// 2018.09.30
+				p.setSecondPart(i)
+				p.stateDate = dateDigitDotDot
+			}
+		case dateDigitDotDot:
+			// iterate all the way through
+			// 26.04.2014 17:24
+			// 26.04.2014, 17:24:37
+			switch r {
+			case ' ', ',':
+				p.stateTime = timeStart
+				if p.yeari > 0 && p.yearlen == 0 {
+					p.yearlen = i - p.yeari
+					p.setYear()
+				} else if p.daylen == 0 {
+					p.daylen = i - p.dayi
+					p.setDay()
 				}
-				switch r {
-				case '+', '-':
-					p.mslen = i - p.msi - 1
-					p.stateTime = timePeriodWsOffset
-				default:
-					if unicode.IsLetter(r) {
-						//     00:07:31.945167 +0000 UTC
-						//     00:00:00.000 +0000 UTC
-						p.stateTime = timePeriodWsOffsetWsAlpha
-						break iterTimeRunes
+				break iterRunes
+			}
+		case dateAlpha:
+			// dateAlphaWS
+			//  Mon Jan _2 15:04:05 2006
+			//  Mon Jan _2 15:04:05 MST 2006
+			//  Mon Jan 02 15:04:05 -0700 2006
+			//  Mon Aug 10 15:44:11 UTC+0100 2015
+			//  Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
+			//  dateAlphaWSDigit
+			//    May 8, 2009 5:57:51 PM
+			//    oct 1, 1970
+			//  dateAlphaWsMonth
+			//    April 8, 2009
+			//  dateAlphaWsMore
+			//    dateAlphaWsAtTime
+			//      January 02, 2006 at 3:04pm MST-07
+			//
+			//  dateAlphaPeriodWsDigit
+			//    oct. 1, 1970
+			// dateWeekdayComma
+			//   Monday, 02 Jan 2006 15:04:05 MST
+			//   Monday, 02-Jan-06 15:04:05 MST
+			//   Monday, 02 Jan 2006 15:04:05 -0700
+			//   Monday, 02 Jan 2006 15:04:05 +0100
+			// dateWeekdayAbbrevComma
+			//   Mon, 02 Jan 2006 15:04:05 MST
+			//   Mon, 02 Jan 2006 15:04:05 -0700
+			//   Thu, 13 Jul 2017 08:58:40 +0100
+			//   Tue, 11 Jul 2017 16:28:13 +0200 (CEST)
+			//   Mon, 02-Jan-06 15:04:05 MST
+			switch {
+			case r == ' ':
+				//      X
+				// April 8, 2009
+				if i > 3 {
+					// Check to see if the alpha is name of month?  or Day?
+					month := strings.ToLower(datestr[0:i])
+					if isMonthFull(month) {
+						p.fullMonth = month
+						// len(" 31, 2018")   = 9
+						if len(datestr[i:]) < 10 {
+							// April 8, 2009
+							p.stateDate = dateAlphaWsMonth
+						} else {
+							p.stateDate = dateAlphaWsMore
+						}
+						p.dayi = i + 1
+						break
 					}
+
+				} else {
+					// This is possibly ambiguous?  May will parse as either though.
+					// So, it could return in-correct format.
+					// May 05, 2005, 05:05:05
+					// May 05 2005, 05:05:05
+					// Jul 05, 2005, 05:05:05
+					p.stateDate = dateAlphaWs
 				}
 
-			case timePeriodWsOffset:
-				// timePeriodWs
-				//   timePeriodWsOffset
-				//     00:07:31.945167 +0000
-				//     00:00:00.000 +0000
-				//     With Extra
-				//       00:00:00.000 +0300 +03
-				//   timePeriodWsOffsetAlpha
-				//     00:07:31.945167 +0000 UTC
-				//     00:00:00.000 +0000 UTC
-				//     03:02:00.001 +0300 MSK m=+0.000000001
-				//   timePeriodWsOffsetColon
-				//     13:31:51.999 -07:00 MST
-				//   timePeriodWsAlpha
-				//     06:20:00.000 UTC
-				switch r {
-				case ':':
-					p.stateTime = timePeriodWsOffsetColon
-				case ' ':
-					p.set(p.offseti, "-0700")
-				case '+', '-':
-					// This really doesn't seem valid, but for some reason when round-tripping a go date
-					// their is an extra +03 printed out.  seems like go bug to me, but, parsing anyway.
-					// 00:00:00.000 +0300 +03
-					// 00:00:00.000 +0300 +0300
-					p.extra = i - 1
-					p.trimExtra()
-					break
-				default:
-					if unicode.IsLetter(r) {
-						// 00:07:31.945167 +0000 UTC
-						// 00:00:00.000 +0000 UTC
-						// 03:02:00.001 +0300 MSK m=+0.000000001
-						p.stateTime = timePeriodWsOffsetWsAlpha
-					}
+			case r == ',':
+				// Mon, 02 Jan 2006
+				// p.moi = 0
+				// p.molen = i
+				if i == 3 {
+					p.stateDate = dateWeekdayAbbrevComma
+					p.set(0, "Mon")
+				} else {
+					p.stateDate = dateWeekdayComma
+					p.skip = i + 2
+					i++
+					// TODO:  lets just make this "skip" as we don't need
+					// the mon, monday, they are all superfelous and not needed
+					// just lay down the skip, no need to fill and then skip
 				}
-			case timePeriodWsOffsetWsAlpha:
-				// 03:02:00.001 +0300 MSK m=+0.000000001
-				// eff you golang
-				if r == '=' && datestr[i-1] == 'm' {
-					p.extra = i - 2
-					p.trimExtra()
-					break
+			case r == '.':
+				// sept. 28, 2017
+				// jan. 28, 2017
+				p.stateDate = dateAlphaPeriodWsDigit
+				if i == 3 {
+					p.molen = i
+					p.set(0, "Jan")
+				} else if i == 4 {
+					// gross
+					datestr = datestr[0:i-1] + datestr[i:]
+					return parseTime(datestr, loc, opts...)
+				} else {
+					return nil, unknownErr(datestr)
+				}
+			}
+
+		case dateAlphaWs:
+			// dateAlphaWsAlpha
+			//   Mon Jan _2 15:04:05 2006
+			//   Mon Jan _2 15:04:05 MST 2006
+			//   Mon Jan 02 15:04:05 -0700 2006
+			//   Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
+			//   Mon Aug 10 15:44:11 UTC+0100 2015
+			//  dateAlphaWsDigit
+			//    May 8, 2009 5:57:51 PM
+			//    May 8 2009 5:57:51 PM
+			//    oct 1, 1970
+			//    oct 7, '70
+			switch {
+			case unicode.IsLetter(r):
+				p.set(0, "Mon")
+				p.stateDate = dateAlphaWsAlpha
+				p.set(i, "Jan")
+			case unicode.IsDigit(r):
+				p.set(0, "Jan")
+				p.stateDate = dateAlphaWsDigit
+				p.dayi = i
+			}
+
+		case dateAlphaWsDigit:
+			// May 8, 2009 5:57:51 PM
+			// May 8 2009 5:57:51 PM
+			// oct 1, 1970
+			// oct 7, '70
+			// oct. 7, 1970
+			if r == ',' {
+				p.daylen = i - p.dayi
+				p.setDay()
+				p.stateDate = dateAlphaWsDigitMore
+			} else if r == ' ' {
+				p.daylen = i - p.dayi
+				p.setDay()
+				p.yeari = i + 1
+				p.stateDate = dateAlphaWsDigitMoreWs
+			} else if unicode.IsLetter(r) {
+				p.stateDate = dateAlphaWsMonthSuffix
+				i--
+			}
+		case dateAlphaWsDigitMore:
+			//       x
+			// May 8, 2009 5:57:51 PM
+			// May 05, 2005, 05:05:05
+			// May 05 2005, 05:05:05
+			// oct 1, 1970
+			// oct 7, '70
+			if r == ' ' {
+				p.yeari = i + 1
+				p.stateDate = dateAlphaWsDigitMoreWs
+			}
+		case dateAlphaWsDigitMoreWs:
+			//            x
+			// May 8, 2009 5:57:51 PM
+			// May 05, 2005, 05:05:05
+			// oct 1, 1970
+			// oct 7, '70
+			switch r {
+			case '\'':
+				p.yeari = i + 1
+			case ' ', ',':
+				//            x
+				// May 8, 2009 5:57:51 PM
+				//            x
+				// May 8, 2009, 5:57:51 PM
+				p.stateDate = dateAlphaWsDigitMoreWsYear
+				p.yearlen = i - p.yeari
+				p.setYear()
+				p.stateTime = timeStart
+				break iterRunes
+			}
+
+		case dateAlphaWsAlpha:
+			// Mon Jan _2 15:04:05 2006
+			// Mon Jan 02 15:04:05 -0700 2006
+			// Mon Jan _2 15:04:05 MST 2006
+			// Mon Aug 10 15:44:11 UTC+0100 2015
+			// Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
+			if r == ' ' {
+				if p.dayi > 0 {
+					p.daylen = i - p.dayi
+					p.setDay()
+					p.yeari = i + 1
+					p.stateDate = dateAlphaWsAlphaYearmaybe
+					p.stateTime = timeStart
+				}
+			} else if unicode.IsDigit(r) {
+				if p.dayi == 0 {
+					p.dayi = i
+				}
+			}
+
+		case dateAlphaWsAlphaYearmaybe:
+			//            x
+			// Mon Jan _2 15:04:05 2006
+			// Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
+			if r == ':' {
+				i = i - 3
+				p.stateDate = dateAlphaWsAlpha
+				p.yeari = 0
+				break iterRunes
+			} else if r == ' ' {
+				// must be year format, not 15:04
+				p.yearlen = i - p.yeari
+				p.setYear()
+				break iterRunes
+			}
+
+		case dateAlphaWsMonth:
+			// April 8, 2009
+			// April 8 2009
+			switch r {
+			case ' ', ',':
+				//       x
+				// June 8, 2009
+				//       x
+				// June 8 2009
+				if p.daylen == 0 {
+					p.daylen = i - p.dayi
+					p.setDay()
+				}
+			case 's', 'S', 'r', 'R', 't', 'T', 'n', 'N':
+				// st, rd, nd, st
+				i--
+				p.stateDate = dateAlphaWsMonthSuffix
+			default:
+				if p.daylen > 0 && p.yeari == 0 {
+					p.yeari = i
+				}
+			}
+		case dateAlphaWsMonthMore:
+			//                  X
+			// January 02, 2006, 15:04:05
+			// January 02 2006, 15:04:05
+			// January 02, 2006 15:04:05
+			// January 02 2006 15:04:05
+			switch r {
+			case ',':
+				p.yearlen = i - p.yeari
+				p.setYear()
+				p.stateTime = timeStart
+				i++
+				break iterRunes
+			case ' ':
+				p.yearlen = i - p.yeari
+				p.setYear()
+				p.stateTime = timeStart
+				break iterRunes
+			}
+		case dateAlphaWsMonthSuffix:
+			//        x
+			// April 8th, 2009
+			// April 8th 2009
+			switch r {
+			case 't', 'T':
+				if p.nextIs(i, 'h') || p.nextIs(i, 'H') {
+					if len(datestr) > i+2 {
+						return parseTime(fmt.Sprintf("%s%s", p.datestr[0:i], p.datestr[i+2:]), loc, opts...)
+					}
+				}
+			case 'n', 'N':
+				if p.nextIs(i, 'd') || p.nextIs(i, 'D') {
+					if len(datestr) > i+2 {
+						return parseTime(fmt.Sprintf("%s%s", p.datestr[0:i], p.datestr[i+2:]), loc, opts...)
+					}
+				}
+			case 's', 'S':
+				if p.nextIs(i, 't') || p.nextIs(i, 'T') {
+					if len(datestr) > i+2 {
+						return parseTime(fmt.Sprintf("%s%s", p.datestr[0:i], p.datestr[i+2:]), loc, opts...)
+					}
+				}
+			case 'r', 'R':
+				if p.nextIs(i, 'd') || p.nextIs(i, 'D') {
+					if len(datestr) > i+2 {
+						return parseTime(fmt.Sprintf("%s%s", p.datestr[0:i], p.datestr[i+2:]), loc, opts...)
+					}
+				}
+			}
+		case dateAlphaWsMore:
+			// January 02, 2006, 15:04:05
+			// January 02 2006, 15:04:05
+			// January 2nd, 2006, 15:04:05
+			// January 2nd 2006, 15:04:05
+			// September 17, 2012 at 5:00pm UTC-05
+			switch {
+			case r == ',':
+				//           x
+				// January 02, 2006, 15:04:05
+				if p.nextIs(i, ' ') {
+					p.daylen = i - p.dayi
+					p.setDay()
+					p.yeari = i + 2
+					p.stateDate = dateAlphaWsMonthMore
+					i++
 				}
 
-			case timePeriodWsOffsetColon:
-				// 13:31:51.999 -07:00 MST
-				switch r {
-				case ' ':
-					p.set(p.offseti, "-07:00")
-				default:
-					if unicode.IsLetter(r) {
-						// 13:31:51.999 -07:00 MST
-						p.tzi = i
-						p.stateTime = timePeriodWsOffsetColonAlpha
-					}
-				}
-			case timePeriodWsOffsetColonAlpha:
-				// continue
-			case timeZ:
-				// timeZ
-				//   15:04:05.99Z
-				// With a time-zone at end after Z
-				// 2006-01-02T15:04:05.999999999Z07:00
-				// 2006-01-02T15:04:05Z07:00
-				// RFC3339     = "2006-01-02T15:04:05Z07:00"
-				// RFC3339Nano = "2006-01-02T15:04:05.999999999Z07:00"
-				if unicode.IsDigit(r) {
-					p.stateTime = timeZDigit
-				}
+			case r == ' ':
+				//           x
+				// January 02 2006, 15:04:05
+				p.daylen = i - p.dayi
+				p.setDay()
+				p.yeari = i + 1
+				p.stateDate = dateAlphaWsMonthMore
+			case unicode.IsDigit(r):
+				//         XX
+				// January 02, 2006, 15:04:05
+				continue
+			case unicode.IsLetter(r):
+				//          X
+				// January 2nd, 2006, 15:04:05
+				p.daylen = i - p.dayi
+				p.setDay()
+				p.stateDate = dateAlphaWsMonthSuffix
+				i--
+			}
+
+		case dateAlphaPeriodWsDigit:
+			//    oct. 7, '70
+			switch {
+			case r == ' ':
+				// continue
+			case unicode.IsDigit(r):
+				p.stateDate = dateAlphaWsDigit
+				p.dayi = i
+			default:
+				return p, unknownErr(datestr)
+			}
+		case dateWeekdayComma:
+			// Monday, 02 Jan 2006 15:04:05 MST
+			// Monday, 02 Jan 2006 15:04:05 -0700
+			// Monday, 02 Jan 2006 15:04:05 +0100
+			// Monday, 02-Jan-06 15:04:05 MST
+			if p.dayi == 0 {
+				p.dayi = i
+			}
+			switch r {
+			case ' ', '-':
+				if p.moi == 0 {
+					p.moi = i + 1
+					p.daylen = i - p.dayi
+					p.setDay()
+				} else if p.yeari == 0 {
+					p.yeari = i + 1
+					p.molen = i - p.moi
+					p.setMonthName()
+				} else {
+					p.stateTime = timeStart
+					break iterRunes
+				}
+			}
+		case dateWeekdayAbbrevComma:
+			// Mon, 02 Jan 2006 15:04:05 MST
+			// Mon, 02 Jan 2006 15:04:05 -0700
+			// Thu, 13 Jul 2017 08:58:40 +0100
+			// Thu, 4 Jan 2018 17:53:36 +0000
+			// Tue, 11 Jul 2017 16:28:13 +0200 (CEST)
+			// Mon, 02-Jan-06 15:04:05 MST
+			switch r {
+			case ' ', '-':
+				if p.dayi == 0 {
+					p.dayi = i + 1
+				} else if p.moi == 0 {
+					p.daylen = i - p.dayi
+					p.setDay()
+					p.moi = i + 1
+				} else if p.yeari == 0 {
+					p.molen = i - p.moi
+					p.setMonthName()
+					p.yeari = i + 1
+				} else {
+					p.yearlen = i - p.yeari
+					p.setYear()
+					p.stateTime = timeStart
+					break iterRunes
+				}
+			}
+
+		default:
+			break iterRunes
+		}
+	}
+	p.coalesceDate(i)
+	if p.stateTime == timeStart {
+		// increment first one, since the i++ occurs at end of loop
+		if i < len(p.datestr) {
+			i++
+		}
+		// ensure we skip any whitespace prefix
+		for ; i < len(datestr); i++ {
+			r := rune(datestr[i])
+			if r != ' ' {
+				break
+			}
+		}
+
+	iterTimeRunes:
+		for ; i < len(datestr); i++ {
+			r := rune(datestr[i])
+
+			//gou.Debugf("%d %s %d iterTimeRunes  %s %s", i, string(r), p.stateTime, p.ds(), p.ts())
+
+			switch p.stateTime {
+			case timeStart:
+				// 22:43:22
+				// 22:43
+				// timeComma
+				//   08:20:13,787
+				// timeWs
+				//   05:24:37 PM
+				//   06:20:00 UTC
+				//   06:20:00 UTC-05
+				//   00:12:00 +0000 UTC
+				//   22:18:00 +0000 UTC m=+0.000000001
+				//   15:04:05 -0700
+				//   15:04:05 -07:00
+				//   15:04:05 2008
+				// timeOffset
+				//   03:21:51+00:00
+				//   19:55:00+0100
+				// timePeriod
+				//   17:24:37.3186369
+				//   00:07:31.945167
+				//   18:31:59.257000000
+				//   00:00:00.000
+				//   timePeriodOffset
+				//     19:55:00.799+0100
+				//     timePeriodOffsetColon
+				//       15:04:05.999-07:00
+				//   timePeriodWs
+				//     timePeriodWsOffset
+				//       00:07:31.945167 +0000
+				//       00:00:00.000 +0000
+				//     timePeriodWsOffsetAlpha
+				//       00:07:31.945167 +0000 UTC
+				//       22:18:00.001 +0000 UTC m=+0.000000001
+				//       00:00:00.000 +0000 UTC
+				//     timePeriodWsAlpha
+				//       06:20:00.000 UTC
+				if p.houri == 0 {
+					p.houri = i
+				}
+				switch r {
+				case ',':
+					// hm, lets just swap out comma for period.  for some reason go
+					// won't parse it.
+					// 2014-05-11 08:20:13,787
+					ds := []byte(p.datestr)
+					ds[i] = '.'
+					return parseTime(string(ds), loc, opts...)
+				case '-', '+':
+					//   03:21:51+00:00
+					//   2014-04-26T17+01:00
+					p.stateTime = timeOffset
+					if p.mini == 0 {
+						p.hourlen = i - p.houri
+					} else if p.seci == 0 {
+						// 22:18+0530
+						p.minlen = i - p.mini
+					} else {
+						p.seclen = i - p.seci
+					}
+					p.offseti = i
+				case '.':
+					p.stateTime = timePeriod
+					p.seclen = i - p.seci
+					p.msi = i + 1
+				case 'Z', 'z':
+					// 2014-04-26T17Z
+					p.stateTime = timeZ
+					if p.mini == 0 {
+						p.hourlen = i - p.houri
+					} else if p.seci == 0 {
+						p.minlen = i - p.mini
+					} else {
+						p.seclen = i - p.seci
+					}
+				case 'a', 'A':
+					if p.nextIs(i, 't') || p.nextIs(i, 'T') {
+						//                    x
+						// September 17, 2012 at 5:00pm UTC-05
+						i++ // skip t
+						if p.nextIs(i, ' ') {
+							//                      x
+							// September 17, 2012 at 5:00pm UTC-05
+							i++         // skip '
+							p.houri = 0 // reset hour
+						}
+					} else {
+						switch {
+						case r == 'a' && p.nextIs(i, 'm'):
+							p.coalesceTime(i)
+							p.set(i, "am")
+						case r == 'A' && p.nextIs(i, 'M'):
+							p.coalesceTime(i)
+							p.set(i, "PM")
+						}
+					}
+
+				case 'p', 'P':
+					// Could be AM/PM
+					switch {
+					case r == 'p' && p.nextIs(i, 'm'):
+						p.coalesceTime(i)
+						p.set(i, "pm")
+					case r == 'P' && p.nextIs(i, 'M'):
+						p.coalesceTime(i)
+						p.set(i, "PM")
+					}
+				case ' ':
+					p.coalesceTime(i)
+					p.stateTime = timeWs
+				case ':':
+					if i == p.houri && p.lenientSeconds {
+						// 2014-04-26 :24:37
+						// fill the missing hour and re-parse as 00:24:37
+						return parseTime(datestr[:i]+"00"+datestr[i:], loc, opts...)
+					}
+					if p.mini == 0 {
+						p.mini = i + 1
+						p.hourlen = i - p.houri
+					} else if p.seci == 0 {
+						p.seci = i + 1
+						p.minlen = i - p.mini
+					}
+				default:
+					if p.impliedMillis && p.seci > 0 && i-p.seci == 2 && hasDigits(datestr[i:], 3) {
+						// 17:24:37123
+						// insert the missing period and re-parse as 17:24:37.123
+						return parseTime(datestr[:i]+"."+datestr[i:], loc, opts...)
+					}
+				}
+			case timeOffset:
+				// 19:55:00+0100
+				// 19:55:00+0100 BST
+				// timeOffsetColon
+				//   15:04:05+07:00
+				//   15:04:05-07:00
+				if r == ':' {
+					p.stateTime = timeOffsetColon
+				} else if unicode.IsLetter(r) && p.tzi == 0 {
+					p.tzi = i
+				}
+			case timeOffsetColon:
+				// 15:04:05+07:00 BST
+				if unicode.IsLetter(r) && p.tzi == 0 {
+					p.tzi = i
+				}
+			case timeWs:
+				// timeWsAlpha
+				//   06:20:00 UTC
+				//   06:20:00 UTC-05
+				//   15:44:11 UTC+0100 2015
+				//   18:04:07 GMT+0100 (GMT Daylight Time)
+				//   17:57:51 MST 2009
+				//   timeWsAMPMMaybe
+				//     05:24:37 PM
+				// timeWsOffset
+				//   15:04:05 -0700
+				//   00:12:00 +0000 UTC
+				//   timeWsOffsetColon
+				//     15:04:05 -07:00
+				//     17:57:51 -0700 2009
+				//     timeWsOffsetColonAlpha
+				//       00:12:00 +00:00 UTC
+				// timeWsYear
+				//     00:12:00 2008
+				//   timeWsYearAlpha
+				//     16:28:13 2017 GMT
+				// timeZ
+				//   15:04:05.99Z
+				switch r {
+				case 'A', 'P':
+					// Could be AM/PM or could be PST or similar
+					p.tzi = i
+					p.stateTime = timeWsAMPMMaybe
+				case '+', '-':
+					p.offseti = i
+					p.stateTime = timeWsOffset
+				default:
+					if unicode.IsLetter(r) {
+						// 06:20:00 UTC
+						// 06:20:00 UTC-05
+						// 15:44:11 UTC+0100 2015
+						// 17:57:51 MST 2009
+						p.tzi = i
+						p.stateTime = timeWsAlpha
+						//break iterTimeRunes
+					} else if unicode.IsDigit(r) {
+						// 00:12:00 2008
+						p.stateTime = timeWsYear
+						p.yeari = i
+					}
+				}
+			case timeWsAlpha:
+				// 06:20:00 UTC
+				// 06:20:00 UTC-05
+				// timeWsAlphaWs
+				//   17:57:51 MST 2009
+				// timeWsAlphaZoneOffset
+				// timeWsAlphaZoneOffsetWs
+				//   timeWsAlphaZoneOffsetWsExtra
+				//     18:04:07 GMT+0100 (GMT Daylight Time)
+				//   timeWsAlphaZoneOffsetWsYear
+				//     15:44:11 UTC+0100 2015
+				switch r {
+				case '+', '-':
+					p.tzlen = i - p.tzi
+					if p.tzlen == 4 {
+						p.setZoneName(" MST")
+					} else if p.tzlen == 3 {
+						p.setZoneName("MST")
+					}
+					p.stateTime = timeWsAlphaZoneOffset
+					p.offseti = i
+				case ' ':
+					// 17:57:51 MST 2009
+					p.tzlen = i - p.tzi
+					if p.tzlen == 4 {
+						p.setZoneName(" MST")
+					} else if p.tzlen == 3 {
+						p.setZoneName("MST")
+					}
+					p.stateTime = timeWsAlphaWs
+					p.yeari = i + 1
+				}
+			case timeWsAlphaWs:
+				//   17:57:51 MST 2009
+
+			case timeWsYear:
+				//   00:12:00 2008
+				// timeWsYearAlpha
+				//   16:28:13 2017 GMT
+				if r == ' ' {
+					// only the redundant UTC zone follows the year
+					zone := datestr[i+1:]
+					if strings.TrimSpace(zone) == "" {
+						break
+					}
+					if !strings.EqualFold(zone, "GMT") && !strings.EqualFold(zone, "UTC") {
+						return nil, unknownErr(datestr)
+					}
+					p.yearlen = i - p.yeari
+					p.setYear()
+					p.tzi = i + 1
+					p.tzlen = len(zone)
+					p.setZoneName("MST")
+					p.stateTime = timeWsYearAlpha
+					break iterTimeRunes
+				}
+
+			case timeWsAlphaZoneOffset:
+				// 06:20:00 UTC-05
+				// timeWsAlphaZoneOffset
+				// timeWsAlphaZoneOffsetWs
+				//   timeWsAlphaZoneOffsetWsExtra
+				//     18:04:07 GMT+0100 (GMT Daylight Time)
+				//   timeWsAlphaZoneOffsetWsYear
+				//     15:44:11 UTC+0100 2015
+				switch r {
+				case ' ':
+					p.set(p.offseti, "-0700")
+					p.yeari = i + 1
+					p.stateTime = timeWsAlphaZoneOffsetWs
+				}
+			case timeWsAlphaZoneOffsetWs:
+				// timeWsAlphaZoneOffsetWs
+				//   timeWsAlphaZoneOffsetWsExtra
+				//     18:04:07 GMT+0100 (GMT Daylight Time)
+				//   timeWsAlphaZoneOffsetWsYear
+				//     15:44:11 UTC+0100 2015
+				if unicode.IsDigit(r) {
+					p.stateTime = timeWsAlphaZoneOffsetWsYear
+				} else {
+					p.extra = i - 1
+					p.stateTime = timeWsAlphaZoneOffsetWsExtra
+				}
+			case timeWsAlphaZoneOffsetWsYear:
+				// 15:44:11 UTC+0100 2015
+				if unicode.IsDigit(r) {
+					p.yearlen = i - p.yeari + 1
+					if p.yearlen == 4 {
+						p.setYear()
+					}
+				}
+			case timeWsAMPMMaybe:
+				// timeWsAMPMMaybe
+				//   timeWsAMPM
+				//     05:24:37 PM
+				//   timeWsAlpha
+				//     00:12:00 PST
+				//     15:44:11 UTC+0100 2015
+				if r == 'M' {
+					//return parse("2006-01-02 03:04:05 PM", datestr, loc)
+					p.stateTime = timeWsAMPM
+					p.tzi = 0
+					p.set(i-1, "PM")
+					if p.hourlen == 2 {
+						p.set(p.houri, "03")
+					} else if p.hourlen == 1 {
+						p.set(p.houri, "3")
+					}
+				} else {
+					p.stateTime = timeWsAlpha
+				}
+
+			case timeWsOffset:
+				// timeWsOffset
+				//   15:04:05 -0700
+				//   timeWsOffsetWsOffset
+				//     17:57:51 -0700 -07
+				//   timeWsOffsetWs
+				//     17:57:51 -0700 2009
+				//     00:12:00 +0000 UTC
+				//   timeWsOffsetColon
+				//     15:04:05 -07:00
+				//     timeWsOffsetColonAlpha
+				//       00:12:00 +00:00 UTC
+				switch r {
+				case ':':
+					p.stateTime = timeWsOffsetColon
+				case ' ':
+					p.setOffset(i)
+					p.yeari = i + 1
+					p.stateTime = timeWsOffsetWs
+				}
+			case timeWsOffsetWs:
+				// 17:57:51 -0700 2009
+				// 00:12:00 +0000 UTC
+				// 22:18:00.001 +0000 UTC m=+0.000000001
+				// w Extra
+				//   17:57:51 -0700 -07
+				switch r {
+				case '=':
+					// eff you golang
+					if datestr[i-1] == 'm' {
+						p.extra = i - 2
+						p.trimExtra()
+						break
+					}
+				case '+', '-':
+					// This really doesn't seem valid, but for some reason when round-tripping a go date
+					// their is an extra +03 printed out.  seems like go bug to me, but, parsing anyway.
+					// 00:00:00 +0300 +03
+					// 00:00:00 +0300 +0300
+					p.extra = i - 1
+					p.stateTime = timeWsOffset
+					p.trimExtra()
+					break
+				default:
+					switch {
+					case unicode.IsDigit(r):
+						p.yearlen = i - p.yeari + 1
+						if p.yearlen == 4 {
+							p.setYear()
+						}
+					case unicode.IsLetter(r):
+						if p.tzi == 0 {
+							p.tzi = i
+						}
+					}
+				}
+
+			case timeWsOffsetColon:
+				// timeWsOffsetColon
+				//   15:04:05 -07:00
+				//   timeWsOffsetColonAlpha
+				//     2015-02-18 00:12:00 +00:00 UTC
+				if unicode.IsLetter(r) {
+					// 2015-02-18 00:12:00 +00:00 UTC
+					p.setOffsetColon(i - 1)
+					p.tzi = i
+					p.stateTime = timeWsOffsetColonAlpha
+					break iterTimeRunes
+				}
+			case timePeriod:
+				// 15:04:05.999999999+07:00
+				// 15:04:05.999999999-07:00
+				// 15:04:05.999999+07:00
+				// 15:04:05.999999-07:00
+				// 15:04:05.999+07:00
+				// 15:04:05.999-07:00
+				// timePeriod
+				//   17:24:37.3186369
+				//   00:07:31.945167
+				//   18:31:59.257000000
+				//   00:00:00.000
+				//   timePeriodOffset
+				//     19:55:00.799+0100
+				//     timePeriodOffsetColon
+				//       15:04:05.999-07:00
+				//   timePeriodWs
+				//     timePeriodWsOffset
+				//       00:07:31.945167 +0000
+				//       00:00:00.000 +0000
+				//       With Extra
+				//         00:00:00.000 +0300 +03
+				//     timePeriodWsOffsetAlpha
+				//       00:07:31.945167 +0000 UTC
+				//       00:00:00.000 +0000 UTC
+				//       22:18:00.001 +0000 UTC m=+0.000000001
+				//     timePeriodWsAlpha
+				//       06:20:00.000 UTC
+				switch r {
+				case ' ':
+					p.mslen = i - p.msi
+					p.stateTime = timePeriodWs
+				case '+', '-':
+					// This really shouldn't happen
+					p.mslen = i - p.msi
+					p.offseti = i
+					p.stateTime = timePeriodOffset
+				case 'Z', 'z':
+					// 15:04:05.99Z
+					p.mslen = i - p.msi
+					p.stateTime = timeZ
+				default:
+					if unicode.IsLetter(r) {
+						// 06:20:00.000 UTC
+						p.mslen = i - p.msi
+						p.stateTime = timePeriodWsAlpha
+					}
+				}
+			case timePeriodOffset:
+				// timePeriodOffset
+				//   19:55:00.799+0100
+				//   timePeriodOffsetColon
+				//     15:04:05.999-07:00
+				//     13:31:51.999-07:00 MST
+				if r == ':' {
+					p.stateTime = timePeriodOffsetColon
+				}
+			case timePeriodOffsetColon:
+				// timePeriodOffset
+				//   timePeriodOffsetColon
+				//     15:04:05.999-07:00
+				//     13:31:51.999 -07:00 MST
+				switch r {
+				case ' ':
+					p.setOffsetColon(i)
+					p.stateTime = timePeriodOffsetColonWs
+					p.tzi = i + 1
+				}
+			case timePeriodOffsetColonWs:
+				// continue
+			case timePeriodWs:
+				// timePeriodWs
+				//   timePeriodWsOffset
+				//     00:07:31.945167 +0000
+				//     00:00:00.000 +0000
+				//   timePeriodWsOffsetAlpha
+				//     00:07:31.945167 +0000 UTC
+				//     00:00:00.000 +0000 UTC
+				//   timePeriodWsOffsetColon
+				//     13:31:51.999 -07:00 MST
+				//   timePeriodWsAlpha
+				//     06:20:00.000 UTC
+				switch r {
+				case '+', '-':
+					if p.offseti == 0 {
+						p.offseti = i
+					}
+					p.mslen = i - p.msi - 1
+					p.stateTime = timePeriodWsOffset
+				default:
+					if unicode.IsLetter(r) {
+						//     00:07:31.945167 +0000 UTC
+						//     00:00:00.000 +0000 UTC
+						p.tzi = i
+						p.stateTime = timePeriodWsOffsetWsAlpha
+						break iterTimeRunes
+					}
+				}
+
+			case timePeriodWsOffset:
+				// timePeriodWs
+				//   timePeriodWsOffset
+				//     00:07:31.945167 +0000
+				//     00:00:00.000 +0000
+				//     With Extra
+				//       00:00:00.000 +0300 +03
+				//   timePeriodWsOffsetAlpha
+				//     00:07:31.945167 +0000 UTC
+				//     00:00:00.000 +0000 UTC
+				//     03:02:00.001 +0300 MSK m=+0.000000001
+				//   timePeriodWsOffsetColon
+				//     13:31:51.999 -07:00 MST
+				//   timePeriodWsAlpha
+				//     06:20:00.000 UTC
+				switch r {
+				case ':':
+					p.stateTime = timePeriodWsOffsetColon
+				case ' ':
+					p.setOffset(i)
+				case '+', '-':
+					// This really doesn't seem valid, but for some reason when round-tripping a go date
+					// their is an extra +03 printed out.  seems like go bug to me, but, parsing anyway.
+					// 00:00:00.000 +0300 +03
+					// 00:00:00.000 +0300 +0300
+					p.extra = i - 1
+					p.trimExtra()
+					break
+				default:
+					if unicode.IsLetter(r) {
+						// 00:07:31.945167 +0000 UTC
+						// 00:00:00.000 +0000 UTC
+						// 03:02:00.001 +0300 MSK m=+0.000000001
+						p.tzi = i
+						p.stateTime = timePeriodWsOffsetWsAlpha
+					}
+				}
+			case timePeriodWsOffsetWsAlpha:
+				// 03:02:00.001 +0300 MSK m=+0.000000001
+				// eff you golang
+				if r == '=' && datestr[i-1] == 'm' {
+					p.extra = i - 2
+					p.trimExtra()
+					break
+				}
+
+			case timePeriodWsOffsetColon:
+				// 13:31:51.999 -07:00 MST
+				switch r {
+				case ' ':
+					p.setOffsetColon(i)
+				default:
+					if unicode.IsLetter(r) {
+						// 13:31:51.999 -07:00 MST
+						p.tzi = i
+						p.stateTime = timePeriodWsOffsetColonAlpha
+					}
+				}
+			case timePeriodWsOffsetColonAlpha:
+				// continue
+			case timeZ:
+				// timeZ
+				//   15:04:05.99Z
+				// With a time-zone at end after Z
+				// 2006-01-02T15:04:05.999999999Z07:00
+				// 2006-01-02T15:04:05Z07:00
+				// RFC3339     = "2006-01-02T15:04:05Z07:00"
+				// RFC3339Nano = "2006-01-02T15:04:05.999999999Z07:00"
+				// With a zone name after the Z
+				// 2006-01-02T15:04:05Z UTC
+				if unicode.IsDigit(r) {
+					p.stateTime = timeZDigit
+				} else if unicode.IsLetter(r) && p.tzi == 0 {
+					p.tzi = i
+				}
+
+			}
+		}
+
+		switch p.stateTime {
+		case timeStart:
+			if p.mini == 0 && p.stateDate == dateYearDashDashT {
+				// 2014-04-26T17
+				p.hourlen = i - p.houri
+			}
+		case timeWsAlphaWs:
+			p.yearlen = i - p.yeari
+			p.setYear()
+		case timeWsYear:
+			p.yearlen = i - p.yeari
+			p.setYear()
+		case timeWsAlphaZoneOffsetWsExtra:
+			p.trimExtra()
+		case timeWsAlphaZoneOffset:
+			// 06:20:00 UTC-05
+			if i-p.offseti < 4 {
+				p.set(p.offseti, "-07")
+			} else {
+				p.set(p.offseti, "-0700")
+			}
+
+		case timePeriod:
+			p.mslen = i - p.msi
+		case timeOffset:
+			// 19:55:00+0100
+			// 00:00:00+005328
+			p.setOffset(i)
+		case timeWsOffset:
+			p.setOffset(i)
+		case timeWsOffsetWs:
+			// 17:57:51 -0700 2009
+			// 00:12:00 +0000 UTC
+		case timeWsOffsetColon:
+			// 17:57:51 -07:00
+			p.setOffsetColon(i)
+		case timeOffsetColon:
+			// 15:04:05+07:00
+			// 00:00:00+00:53:28
+			p.setOffsetColon(i)
+		case timePeriodOffset:
+			// 19:55:00.799+0100
+			// 17:24:37.123456+00
+			p.setOffset(i)
+		case timePeriodOffsetColon:
+			p.setOffsetColon(i)
+		case timePeriodWsOffsetColon:
+			// 13:31:51.999 -07:00
+			p.setOffsetColon(i)
+		case timePeriodWsOffsetColonAlpha:
+			p.tzlen = i - p.tzi
+			switch p.tzlen {
+			case 3:
+				p.setZoneName("MST")
+			case 4:
+				p.setZoneName("MST ")
+			}
+		case timePeriodWsOffset:
+			p.setOffset(i)
+		}
+		if name := p.zoneName(); name != strings.ToUpper(name) && strings.HasPrefix(string(p.format[p.tzi:]), name) {
+			// 2012-08-03 13:31:59 mst is read as MST, the zone name a
+			// literal of the layout as written
+			p.tzlen = len(name)
+			p.setZoneName(strings.ToUpper(name))
+		}
+		p.coalesceTime(i)
+	}
+
+	switch p.stateDate {
+	case dateDigit:
+		// unixy timestamps ish
+		//  example              ct type
+		//  1499979655583057426  19 nanoseconds
+		//  1499979795437000     16 micro-seconds
+		//  20180722105203       14 yyyyMMddhhmmss
+		//  1499979795437        13 milliseconds
+		//  1332151919           10 seconds
+		//  20140601             8  yyyymmdd, see WithEightDigitOrder
+		//  201404               6  yyyymm, see WithCompactYearMonth
+		//  2014                 4  yyyy
+		t := time.Time{}
+		if len(datestr) == len("1499979655583057426") { // 19
+			// nano-seconds
+			if nanoSecs, err := strconv.ParseInt(datestr, 10, 64); err == nil {
+				t = time.Unix(0, nanoSecs)
+			}
+		} else if len(datestr) == len("1499979795437000") { // 16
+			// micro-seconds
+			if microSecs, err := strconv.ParseInt(datestr, 10, 64); err == nil {
+				t = time.Unix(0, microSecs*1000)
+			}
+		} else if len(datestr) == len("yyyyMMddhhmmss") { // 14
+			// yyyyMMddhhmmss
+			p.format = []byte("20060102150405")
+			return p, nil
+		} else if len(datestr) == len("1332151919000") { // 13
+			if miliSecs, err := strconv.ParseInt(datestr, 10, 64); err == nil {
+				t = time.Unix(0, miliSecs*1000*1000)
+			}
+		} else if len(datestr) == len("1332151919") { //10
+			if secs, err := strconv.ParseInt(datestr, 10, 64); err == nil {
+				t = time.Unix(secs, 0)
+			}
+		} else if len(datestr) == len("20140601") {
+			switch p.eightDigitOrder {
+			case OrderDMY:
+				// ddmmyyyy
+				p.format = []byte("02012006")
+			case OrderMDY:
+				// mmddyyyy
+				p.format = []byte("01022006")
+			default:
+				p.format = []byte("20060102")
+			}
+			return p, nil
+		} else if len(datestr) == len("201404") && p.compactYearMonth {
+			// yyyymm
+			p.format = []byte("200601")
+			return p, nil
+		} else if len(datestr) == len("2014") {
+			p.format = []byte("2006")
+			return p, nil
+		} else if len(datestr) < 4 {
+			return nil, fmt.Errorf("unrecognized format, too short %v", datestr)
+		}
+		if !t.IsZero() {
+			if loc == nil {
+				p.t = &t
+				return p, nil
+			}
+			t = t.In(loc)
+			p.t = &t
+			return p, nil
+		}
+
+	case dateYearDash:
+		// 2006-01
+		return p, nil
+
+	case dateYearDashDash:
+		// 2006-01-02
+		// 2006-1-02
+		// 2006-1-2
+		// 2006-01-2
+		return p, nil
+
+	case dateYearDashAlphaDash:
+		// 2013-Feb-03
+		// 2013-Feb-3
+		p.daylen = i - p.dayi
+		p.setDay()
+		return p, nil
+
+	case dateYearDashDashWs:
+		// 2013-04-01
+		return p, nil
+
+	case dateYearDashDashT:
+		return p, nil
+
+	case dateDigitDashAlphaDash:
+		// 13-Feb-03   ambiguous
+		// 28-Feb-03   ambiguous
+		// 29-Jun-2016
+		length := len(datestr) - (p.moi + p.molen + 1)
+		if length == 4 {
+			p.yearlen = 4
+			p.set(p.yeari, "2006")
+			// We now also know that part1 was the day
+			p.dayi = 0
+			p.daylen = p.part1Len
+			p.setDay()
+		} else if length == 2 {
+			// We have no idea if this is
+			// yy-mon-dd   OR  dd-mon-yy
+			//
+			// We are going to ASSUME (bad, bad) that it is dd-mon-yy  which is a horible assumption
+			p.ambiguousMD = true
+			p.yearlen = 2
+			p.set(p.yeari, "06")
+			// We now also know that part1 was the day
+			p.dayi = 0
+			p.daylen = p.part1Len
+			p.setDay()
+		}
+
+		return p, nil
+
+	case dateDigitDot:
+		// 2014.05
+		// 2014.103   day of the year
+		if day := datestr[p.moi:i]; p.yearlen == 4 && allDigits(day) && (len(day) == 3 || day > "12" && len(day) == 2) {
+			year, _ := strconv.Atoi(datestr[:4])
+			yday, _ := strconv.Atoi(day)
+			t, err := ordinalDate(year, yday)
+			if err != nil {
+				return nil, err
+			}
+			if p.loc != nil {
+				t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, p.loc)
+			}
+			// 2006.002, or 2006.__2 for the 2 digits of 2014.13
+			if len(day) == 3 {
+				p.set(p.moi, "002")
+			} else {
+				p.format = append(p.format[:p.moi], "__2"...)
+			}
+			p.t = &t
+			return p, nil
+		}
+		p.molen = i - p.moi
+		p.setMonth()
+		return p, nil
+
+	case dateDigitDotDot:
+		// 26.04.2014, 17:24
+		// 03.31.1981
+		// 3.31.2014
+		// 3.2.1981
+		// 3.2.81
+		// 08.21.71
+		// 2018.09.30
+		return p, nil
+
+	case dateDigitWsMoYear:
+		// 2 Jan 2018
+		// 2 Jan 18
+		// 2 Jan 2018 23:59
+		// 02 Jan 2018 23:59
+		// 12 Feb 2006, 19:17
+		return p, nil
+
+	case dateDigitWsMolong:
+		// 18 January 2018
+		// 8 January 2018
+		if p.daylen == 2 {
+			p.format = []byte("02 January 2006")
+			return p, nil
+		}
+		p.format = []byte("2 January 2006")
+		return p, nil // parse("2 January 2006", datestr, loc)
+
+	case dateDigitAlphaDigit:
+		// 26Apr2014
+		// 26Apr2014:17:24:37 +0100
+		return p, nil
+
+	case dateAlphaWsMonth:
+		p.yearlen = i - p.yeari
+		p.setYear()
+		return p, nil
+
+	case dateAlphaWsMonthMore:
+		return p, nil
+
+	case dateAlphaWsDigitMoreWs:
+		// oct 1, 1970
+		p.yearlen = i - p.yeari
+		p.setYear()
+		return p, nil
+
+	case dateAlphaWsDigitMoreWsYear:
+		// May 8, 2009 5:57:51 PM
+		// Jun 7, 2005, 05:57:51
+		return p, nil
+
+	case dateAlphaWsAlpha:
+		return p, nil
+
+	case dateAlphaWsAlphaYearmaybe:
+		return p, nil
+
+	case dateDigitSlash, dateDigitDashDigit:
+		// 3/1/2014
+		// 10/13/2014
+		// 01/02/2006
+		// 2014/10/13
+		// 04-26-2014
+		// 04/26   the year comes from the base date
+		// 04/2014 month/year
+		if p.setMonthYear() {
+			return p, nil
+		}
+		if p.yeari > 0 && p.yearlen == 0 {
+			// 04/26/
+			return nil, unknownErr(datestr)
+		}
+		p.yearless = p.yeari == 0 && p.yearlen == 0
+		return p, nil
+
+	case dateDigitChineseYear:
+		// dateDigitChineseYear
+		//   2014年04月08日
+		p.format = []byte("2006年01月02日")
+		return p, nil
+
+	case dateDigitChineseYearWs:
+		//   2014年04月08日 19:17:22
+		//   2014年04月08日 19时17分22秒
+		//   2014年04月08日 19时17分
+		switch {
+		case strings.HasSuffix(datestr, "秒"):
+			p.format = []byte("2006年01月02日 15时04分05秒")
+		case strings.HasSuffix(datestr, "分"):
+			p.format = []byte("2006年01月02日 15时04分")
+		default:
+			p.format = []byte("2006年01月02日 15:04:05")
+		}
+		return p, nil
+
+	case dateWeekdayComma:
+		// Monday, 02 Jan 2006 15:04:05 -0700
+		// Monday, 02 Jan 2006 15:04:05 +0100
+		// Monday, 02-Jan-06 15:04:05 MST
+		return p, nil
+
+	case dateWeekdayAbbrevComma:
+		// Mon, 02-Jan-06 15:04:05 MST
+		// Mon, 02 Jan 2006 15:04:05 MST
+		return p, nil
+
+	}
+
+	return nil, unknownErr(datestr)
+}
+
+type parser struct {
+	loc         *time.Location
+	order       FieldOrder
+	ambiguousMD bool
+	yearless    bool
+	utcTime     bool
+	dateSep     rune
+	stateDate   dateState
+	stateTime   timeState
+	format      []byte
+	datestr     string
+	fullMonth   string
+	skip        int
+	extra       int
+	part1Len    int
+	yeari       int
+	yearlen     int
+	moi         int
+	molen       int
+	dayi        int
+	daylen      int
+	houri       int
+	hourlen     int
+	mini        int
+	minlen      int
+	seci        int
+	seclen      int
+	msi         int
+	mslen       int
+	offseti     int
+	offsetlen   int
+	tzi         int
+	tzlen       int
+	t           *time.Time
+
+	// options
+	lang               string
+	orderSet           bool
+	relative           bool
+	impliedMillis      bool
+	keepFractionDigits bool
+	weekStart          time.Weekday
+	baseDate           time.Time
+	clock              Clock
+	precisionMode      PrecisionMode
+	weekdayDirection   WeekdayDirection
+	pedanticRanges     bool
+	sentinels          map[string]time.Time
+	asciiOnly          bool
+	lenientSeconds     bool
+	flexibleEpoch      bool
+	compactYearMonth   bool
+	dotTime            bool
+	validateWeekday    bool
+	localDesignator    bool
+	strictRFC3339      bool
+	trimPunctuation    bool
+	monthNames         map[string]time.Month
+	plusSeparator      bool
+	eightDigitOrder    FieldOrder
+	assumeCentury      bool
+	noHeuristics       bool
+	orderPreferred     bool
+	goLayoutTokens     bool
+	spacePadding       bool
+	columnInference    bool
+
+	// after are applied in order to the parsed time
+	after []func(time.Time) (time.Time, error)
+	// relayouts map the layout back through each rewrite, see layout
+	relayouts []func(layout string) string
+}
+
+func newParser(dateStr string, loc *time.Location, opts ...ParserOption) (*parser, error) {
+	p := parser{
+		stateDate:       dateStart,
+		stateTime:       timeIgnore,
+		datestr:         dateStr,
+		loc:             loc,
+		order:           OrderMDY,
+		weekStart:       time.Monday,
+		eightDigitOrder: OrderYMD,
+	}
+	p.format = []byte(dateStr)
+	for _, opt := range opts {
+		if err := opt(&p); err != nil {
+			return nil, err
+		}
+	}
+	if !p.orderSet && languages[p.lang].dayFirst {
+		p.order = OrderDMY
+	}
+	return &p, nil
+}
+
+// relativeTime returns the time of a relative date expression, or nil
+// if the date-string is not one.
+func (p *parser) relativeTime(datestr string) (*time.Time, error) {
+	lower := strings.ToLower(datestr)
+	switch {
+	case strings.HasPrefix(lower, "epoch"):
+		// epoch+86400
+		// epoch-1h
+		t := time.Unix(0, 0)
+		if offset := datestr[len("epoch"):]; offset != "" {
+			if offset[0] != '+' && offset[0] != '-' {
+				return nil, fmt.Errorf("Invalid epoch offset in %q", datestr)
+			}
+			if secs, err := strconv.ParseInt(offset, 10, 64); err == nil {
+				t = time.Unix(secs, 0)
+			} else if d, err := time.ParseDuration(offset); err == nil {
+				t = t.Add(d)
+			} else {
+				return nil, fmt.Errorf("Invalid epoch offset in %q", datestr)
+			}
+		}
+		if p.loc != nil {
+			t = t.In(p.loc)
+		}
+		return &t, nil
+	}
+	switch strings.TrimSpace(lower) {
+	case "now":
+		t := p.now()
+		if p.loc != nil {
+			t = t.In(p.loc)
+		}
+		return &t, nil
+	case "today":
+		t := p.today()
+		return &t, nil
+	case "yesterday":
+		t := p.today().AddDate(0, 0, -1)
+		return &t, nil
+	case "tomorrow":
+		t := p.today().AddDate(0, 0, 1)
+		return &t, nil
+	}
+	if t, err := p.militaryTime(datestr); t != nil || err != nil {
+		// 1430 hrs
+		// 1430Z
+		return t, err
+	}
+	if t, err := p.weekdayOrdinal(datestr); t != nil || err != nil {
+		// Monday the 26th
+		return t, err
+	}
+	if day, ok := weekdayName(strings.TrimSpace(datestr)); ok {
+		// Friday
+		base := p.today()
+		next := (int(day) - int(base.Weekday()) + 7) % 7
+		prev := (int(base.Weekday()) - int(day) + 7) % 7
+		var days int
+		switch p.weekdayDirection {
+		case Previous:
+			if prev == 0 {
+				prev = 7
+			}
+			days = -prev
+		case Nearest:
+			if next <= prev {
+				days = next
+			} else {
+				days = -prev
+			}
+		default:
+			if next == 0 {
+				next = 7
+			}
+			days = next
+		}
+		t := base.AddDate(0, 0, days)
+		return &t, nil
+	}
+	return nil, nil
+}
+
+// weekdayOrdinal returns midnight of the day of the month of the base
+// date of "Monday the 26th", or nil if the date-string is not one.  Under
+// ValidateWeekday the day must be on the weekday.
+func (p *parser) weekdayOrdinal(datestr string) (*time.Time, error) {
+	fields := strings.Fields(datestr)
+	if len(fields) != 3 || !strings.EqualFold(fields[1], "the") {
+		return nil, nil
+	}
+	day, ok := weekdayName(strings.TrimSuffix(fields[0], ","))
+	if !ok {
+		return nil, nil
+	}
+	n, ok := ordinalNumber(fields[2])
+	if !ok {
+		return nil, fmt.Errorf("Invalid ordinal day in %q", datestr)
+	}
+	base := p.today()
+	t := time.Date(base.Year(), base.Month(), n, 0, 0, 0, 0, base.Location())
+	if t.Day() != n {
+		return nil, fmt.Errorf("Invalid date %q in %s", datestr, base.Format("January 2006"))
+	}
+	if p.validateWeekday && t.Weekday() != day {
+		return nil, fmt.Errorf("%s is not a %s in %q", t.Format("2006-01-02"), day, datestr)
+	}
+	return &t, nil
+}
+
+// ordinalNumber is the number of an english ordinal, 1st, 2nd, 3rd or
+// 26th, its suffix matching the number.
+func ordinalNumber(s string) (int, bool) {
+	if len(s) < 3 || !allDigits(s[:len(s)-2]) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s[:len(s)-2])
+	if err != nil {
+		return 0, false
+	}
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return n, strings.EqualFold(s[len(s)-2:], suffix)
+}
+
+// militaryTime returns the time of the military time of day "1430 hrs",
+// or "1430Z" in a military zone, on the base date, or nil if the
+// date-string is not one.
+func (p *parser) militaryTime(datestr string) (*time.Time, error) {
+	s := strings.TrimSpace(datestr)
+	if len(s) < 5 || !hasDigits(s, 4) {
+		return nil, nil
+	}
+	suffix := strings.TrimSpace(s[4:])
+	var loc *time.Location
+	switch {
+	case strings.EqualFold(suffix, "hrs"):
+		// 1430 hrs, in the parse location
+	case len(s) == 5:
+		// 1430Z
+		offset, ok := militaryZone(s[4])
+		if !ok {
+			return nil, nil
+		}
+		loc = time.UTC
+		if offset != 0 {
+			loc = time.FixedZone(s[4:], offset*60*60)
+		}
+	default:
+		return nil, nil
+	}
+	hour, _ := strconv.Atoi(s[:2])
+	minute, _ := strconv.Atoi(s[2:4])
+	if hour > 23 || minute > 59 {
+		return nil, fmt.Errorf("Invalid military time %q", datestr)
+	}
+	base := p.today()
+	if loc == nil {
+		loc = base.Location()
+	}
+	t := time.Date(base.Year(), base.Month(), base.Day(), hour, minute, 0, 0, loc)
+	return &t, nil
+}
+
+// militaryZone is the offset, in hours east of UTC, of a military zone
+// letter: Z is UTC, A to M are east skipping the local J, N to Y west.
+func militaryZone(letter byte) (int, bool) {
+	switch {
+	case letter == 'Z':
+		return 0, true
+	case letter >= 'A' && letter <= 'I':
+		return int(letter-'A') + 1, true
+	case letter >= 'K' && letter <= 'M':
+		return int(letter-'K') + 10, true
+	case letter >= 'N' && letter <= 'Y':
+		return -int(letter-'N') - 1, true
+	}
+	return 0, false
+}
+
+// timeFirst rewrites the time first "at 5:24 PM on April 26, 2014" into
+// the date first "April 26, 2014 5:24 PM", the "on" being optional.
+func timeFirst(datestr string) (string, bool) {
+	fields := strings.Fields(datestr)
+	if len(fields) < 3 || !strings.EqualFold(fields[0], "at") {
+		return datestr, false
+	}
+	if !isClock(fields[1]) {
+		return datestr, false
+	}
+	tm, rest := fields[1:2], fields[2:]
+	switch strings.ToLower(rest[0]) {
+	case "am", "pm":
+		tm, rest = fields[1:3], fields[3:]
+	}
+	if len(rest) > 0 && strings.EqualFold(rest[0], "on") {
+		rest = rest[1:]
+	}
+	if len(rest) == 0 {
+		return datestr, false
+	}
+	return strings.Join(rest, " ") + " " + strings.Join(tm, " "), true
+}
+
+// timeFirstLayout maps the layout of ds, the date first rewrite of a time
+// first date-string, to one of datestr, "at 3:04 PM on January 02, 2006"
+// for "at 5:24 PM on April 26, 2014".
+func timeFirstLayout(layout, ds, datestr string) string {
+	lt, dt := layoutTokens(layout), layoutTokens(ds)
+	if len(lt) != len(dt) {
+		return layout
+	}
+	// the date and time of ds are split at a space, the last one before a
+	// time found ahead of the date in datestr
+	for n := len(dt) - 1; n > 0; n-- {
+		if dt[n] != " " {
+			continue
+		}
+		date, tm := strings.Join(dt[:n], ""), strings.Join(dt[n+1:], "")
+		i := strings.Index(datestr, tm)
+		j := strings.LastIndex(datestr, date)
+		if i < 0 || j < i+len(tm) {
+			continue
+		}
+		return datestr[:i] + strings.Join(lt[n+1:], "") + datestr[i+len(tm):j] +
+			strings.Join(lt[:n], "") + datestr[j+len(date):]
+	}
+	return layout
+}
+
+// ungroupDigits drops the thousands separators of a grouped number,
+// 1,384,216,367, and is false for anything else.
+func ungroupDigits(datestr string) (string, bool) {
+	groups := strings.Split(datestr, ",")
+	if len(groups) < 2 || len(groups[0]) > 3 || !allDigits(groups[0]) {
+		return datestr, false
+	}
+	for _, group := range groups[1:] {
+		if len(group) != 3 || !allDigits(group) {
+			return datestr, false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+// trimComment drops the trailing comment of "04:08:03 +0100 (UTC+01:00)"
+// following an offset, whose digits would otherwise be read as part of
+// the date.  Comments without digits, (CEST), are left to the layout.
+func trimComment(datestr string) (string, bool) {
+	open := strings.LastIndex(datestr, " (")
+	if open < 0 || !strings.HasSuffix(datestr, ")") || !strings.ContainsAny(datestr[open:], "0123456789") {
+		return datestr, false
+	}
+	rest := strings.TrimRight(datestr[:open], " ")
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return datestr, false
+	}
+	// -0500  GMT+0100  04:08:03+01:00  04:08:03Z
+	last := fields[len(fields)-1]
+	if n := len(last); n > 1 && (last[n-1] == 'Z' || last[n-1] == 'z') && unicode.IsDigit(rune(last[n-2])) {
+		return rest, true
+	}
+	offset := last[strings.LastIndexAny(last, "+-")+1:]
+	if len(offset) < 2 || len(offset) == len(last) {
+		return datestr, false
+	}
+	for _, r := range offset {
+		if r != ':' && !unicode.IsDigit(r) {
+			return datestr, false
+		}
+	}
+	return rest, true
+}
+
+// trimWeekday drops the weekday annotation of "2014-04-26 (Sat)", or of
+// "2014/04/26(土) 17:24" between the date and time, returning the weekday,
+// the names of the parser language being read as well as the english ones.
+func (p *parser) trimWeekday(datestr string) (string, time.Weekday, bool) {
+	open := strings.LastIndexByte(datestr, '(')
+	if open < 1 {
+		return datestr, 0, false
+	}
+	end := strings.IndexByte(datestr[open:], ')')
+	if end < 0 {
+		return datestr, 0, false
+	}
+	end += open
+	name := strings.TrimSpace(datestr[open+1 : end])
+	day, ok := weekdayName(name)
+	if lang := languages[p.lang]; !ok && lang.weekday != nil {
+		day, ok = lang.weekday(name)
+	}
+	if !ok {
+		return datestr, 0, false
+	}
+	ds := strings.TrimRight(datestr[:open], " ")
+	if rest := strings.TrimSpace(datestr[end+1:]); rest != "" {
+		ds += " " + rest
+	}
+	return ds, day, true
+}
 
+// trimTrailingPunctuation drops the trailing .,;:!? of a date-string but
+// the period of an abbreviated month or weekday name.
+func trimTrailingPunctuation(datestr string) string {
+	for n := len(datestr); n > 0; n = len(datestr) {
+		switch datestr[n-1] {
+		case ',', ';', ':', '!', '?':
+		case '.':
+			word := datestr[strings.LastIndexAny(datestr[:n-1], " ,")+1 : n-1]
+			if _, ok := monthName(word); ok && len(word) == 3 {
+				return datestr
 			}
+			if _, ok := weekdayName(word); ok && len(word) == 3 {
+				return datestr
+			}
+		default:
+			return datestr
 		}
+		datestr = datestr[:n-1]
+	}
+	return datestr
+}
 
-		switch p.stateTime {
-		case timeWsAlphaWs:
-			p.yearlen = i - p.yeari
-			p.setYear()
-		case timeWsYear:
-			p.yearlen = i - p.yeari
-			p.setYear()
-		case timeWsAlphaZoneOffsetWsExtra:
-			p.trimExtra()
-		case timeWsAlphaZoneOffset:
-			// 06:20:00 UTC-05
-			if i-p.offseti < 4 {
-				p.set(p.offseti, "-07")
-			} else {
-				p.set(p.offseti, "-0700")
-			}
+// dropMonthComma drops the comma at i of "26 April, 2014" or
+// "26, April 2014", the comma after or before a month name, leaving the
+// "26 April 2014" parsed instead.
+func dropMonthComma(datestr string, i int) (string, bool) {
+	if i+1 >= len(datestr) || datestr[i+1] != ' ' {
+		return datestr, false
+	}
+	var word string
+	if j := strings.LastIndexByte(datestr[:i], ' '); j >= 0 {
+		// 26 April, 2014
+		word = datestr[j+1 : i]
+	} else if fields := strings.Fields(datestr[i+1:]); len(fields) > 0 {
+		// 26, April 2014
+		word = fields[0]
+	}
+	if _, ok := monthName(word); !ok {
+		return datestr, false
+	}
+	return datestr[:i] + datestr[i+1:], true
+}
 
-		case timePeriod:
-			p.mslen = i - p.msi
-		case timeOffset:
-			// 19:55:00+0100
-			p.set(p.offseti, "-0700")
-		case timeWsOffset:
-			p.set(p.offseti, "-0700")
-		case timeWsOffsetWs:
-			// 17:57:51 -0700 2009
-			// 00:12:00 +0000 UTC
-		case timeWsOffsetColon:
-			// 17:57:51 -07:00
-			p.set(p.offseti, "-07:00")
-		case timeOffsetColon:
-			// 15:04:05+07:00
-			p.set(p.offseti, "-07:00")
-		case timePeriodOffset:
-			// 19:55:00.799+0100
-			p.set(p.offseti, "-0700")
-		case timePeriodOffsetColon:
-			p.set(p.offseti, "-07:00")
-		case timePeriodWsOffsetColonAlpha:
-			p.tzlen = i - p.tzi
-			switch p.tzlen {
-			case 3:
-				p.set(p.tzi, "MST")
-			case 4:
-				p.set(p.tzi, "MST ")
+// isoWeekFirst rewrites the ISO 8601 week date of "2014-W15-2T09:00:00Z"
+// or the compact "2014W152T09:00:00Z" and "2014W15-2T09:00:00Z" into the
+// calendar date "2014-04-08T09:00:00Z", the week day being required when
+// a time follows.
+func isoWeekFirst(datestr string) (string, bool, error) {
+	if len(datestr) < 7 || !hasDigits(datestr, 4) {
+		return datestr, false, nil
+	}
+	var week int
+	day, rest := 1, ""
+	switch {
+	case len(datestr) >= 8 && datestr[4:6] == "-W" && hasDigits(datestr[6:], 2):
+		// 2014-W15-2
+		week, _ = strconv.Atoi(datestr[6:8])
+		if rest = datestr[8:]; rest != "" {
+			if len(rest) < 2 || rest[0] != '-' || !hasDigits(rest[1:], 1) {
+				return datestr, false, fmt.Errorf("Invalid ISO week date %q", datestr)
 			}
-		case timePeriodWsOffset:
-			p.set(p.offseti, "-0700")
+			day, rest = int(rest[1]-'0'), rest[2:]
 		}
-		p.coalesceTime(i)
+	case datestr[4] == 'W' && allDigits(datestr[5:7]):
+		// 2014W152
+		// 2014W15-2
+		week, _ = strconv.Atoi(datestr[5:7])
+		if rest = datestr[7:]; rest != "" {
+			if rest[0] == '-' {
+				rest = rest[1:]
+			}
+			if !hasDigits(rest, 1) {
+				return datestr, false, fmt.Errorf("Invalid ISO week date %q", datestr)
+			}
+			day, rest = int(rest[0]-'0'), rest[1:]
+		}
+	default:
+		return datestr, false, nil
+	}
+	year, _ := strconv.Atoi(datestr[:4])
+	if rest != "" && rest[0] != 'T' && rest[0] != 't' && rest[0] != ' ' {
+		return datestr, false, fmt.Errorf("Invalid ISO week date %q", datestr)
+	}
+	t, err := isoWeekDate(year, week, day)
+	if err != nil {
+		return datestr, false, err
 	}
+	return t.Format("2006-01-02") + rest, true, nil
+}
 
-	switch p.stateDate {
-	case dateDigit:
-		// unixy timestamps ish
-		//  example              ct type
-		//  1499979655583057426  19 nanoseconds
-		//  1499979795437000     16 micro-seconds
-		//  20180722105203       14 yyyyMMddhhmmss
-		//  1499979795437        13 milliseconds
-		//  1332151919           10 seconds
-		//  20140601             8  yyyymmdd
-		//  2014                 4  yyyy
-		t := time.Time{}
-		if len(datestr) == len("1499979655583057426") { // 19
-			// nano-seconds
-			if nanoSecs, err := strconv.ParseInt(datestr, 10, 64); err == nil {
-				t = time.Unix(0, nanoSecs)
-			}
-		} else if len(datestr) == len("1499979795437000") { // 16
-			// micro-seconds
-			if microSecs, err := strconv.ParseInt(datestr, 10, 64); err == nil {
-				t = time.Unix(0, microSecs*1000)
+// dayOfYearFirst rewrites the ordinal "Day 103 of 2014 14:30" into the
+// calendar date "2014-04-13 14:30", the hh:mm or hh:mm:ss time being
+// optional.
+func dayOfYearFirst(datestr string) (string, bool, error) {
+	fields := strings.Fields(datestr)
+	if len(fields) < 4 || !strings.EqualFold(fields[0], "day") || !strings.EqualFold(fields[2], "of") {
+		return datestr, false, nil
+	}
+	if !allDigits(fields[1]) || len(fields[3]) != 4 || !allDigits(fields[3]) {
+		return datestr, false, fmt.Errorf("Invalid day of year %q", datestr)
+	}
+	rest := fields[4:]
+	if len(rest) > 1 || (len(rest) == 1 && !isClock(rest[0])) {
+		return datestr, false, fmt.Errorf("Invalid day of year %q", datestr)
+	}
+	year, _ := strconv.Atoi(fields[3])
+	yday, _ := strconv.Atoi(fields[1])
+	t, err := ordinalDate(year, yday)
+	if err != nil {
+		return datestr, false, err
+	}
+	return strings.Join(append([]string{t.Format("2006-01-02")}, rest...), " "), true, nil
+}
+
+// unambiguous is true for the formats WithHeuristicsDisabled parses, ISO
+// 8601 and so RFC 3339, and RFC 1123.
+func unambiguous(datestr string) bool {
+	if _, err := ParseISO(datestr); err == nil {
+		return true
+	}
+	for _, layout := range []string{time.RFC1123, time.RFC1123Z} {
+		if _, err := time.Parse(layout, datestr); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// centuryYear is the year nearest base, the earlier of a tie, whose four
+// digits hold the digits of the short year in order, see
+// WithAssumeCentury.
+func centuryYear(base int, short string) int {
+	for d := 0; d <= 9999; d++ {
+		for _, year := range []int{base - d, base + d} {
+			if year < 0 || year > 9999 {
+				continue
 			}
-		} else if len(datestr) == len("yyyyMMddhhmmss") { // 14
-			// yyyyMMddhhmmss
-			p.format = []byte("20060102150405")
-			return p, nil
-		} else if len(datestr) == len("1332151919000") { // 13
-			if miliSecs, err := strconv.ParseInt(datestr, 10, 64); err == nil {
-				t = time.Unix(0, miliSecs*1000*1000)
+			digits, j := fmt.Sprintf("%04d", year), 0
+			for i := 0; i < len(digits) && j < len(short); i++ {
+				if digits[i] == short[j] {
+					j++
+				}
 			}
-		} else if len(datestr) == len("1332151919") { //10
-			if secs, err := strconv.ParseInt(datestr, 10, 64); err == nil {
-				t = time.Unix(secs, 0)
+			if j == len(short) {
+				return year
 			}
-		} else if len(datestr) == len("20140601") {
-			p.format = []byte("20060102")
-			return p, nil
-		} else if len(datestr) == len("2014") {
-			p.format = []byte("2006")
-			return p, nil
-		} else if len(datestr) < 4 {
-			return nil, fmt.Errorf("unrecognized format, too short %v", datestr)
 		}
-		if !t.IsZero() {
-			if loc == nil {
-				p.t = &t
-				return p, nil
+	}
+	return base
+}
+
+// spacePadded rewrites the space padded fields following the slashes or
+// dashes of "2014/ 4/ 8" or "2014/ 12/ 8" as zero padded, "2014/04/08"
+// and "2014/12/08".  A field padded with more than one space,
+// "2014/  4/ 8", is an error.
+func spacePadded(datestr string) (string, bool, error) {
+	var ds []byte
+	padded := false
+	for i := 0; i < len(datestr); i++ {
+		ds = append(ds, datestr[i])
+		if i == 0 || i+2 >= len(datestr) || (datestr[i] != '/' && datestr[i] != '-') ||
+			datestr[i-1] < '0' || datestr[i-1] > '9' || datestr[i+1] != ' ' {
+			continue
+		}
+		n := 0
+		for i+2+n < len(datestr) && datestr[i+2+n] >= '0' && datestr[i+2+n] <= '9' {
+			n++
+		}
+		if end := i + 2 + n; n == 0 || n > 2 || (end < len(datestr) && datestr[end] != '/' && datestr[end] != '-' && datestr[end] != ' ') {
+			if n == 0 && datestr[i+2] == ' ' {
+				return datestr, false, fmt.Errorf("Field padded with more than one space in %q", datestr)
 			}
-			t = t.In(loc)
-			p.t = &t
-			return p, nil
+			continue
 		}
+		if n == 1 {
+			// the 4 of / 4
+			ds = append(ds, '0')
+		}
+		// the space of / 12
+		i++
+		padded = true
+	}
+	return string(ds), padded, nil
+}
 
-	case dateYearDash:
-		// 2006-01
-		return p, nil
+// spacePaddedLayout maps the layout of ds, zero padded by spacePadded, to
+// one of the space padded datestr, the _2 of a day and a space before the 1
+// of a month, as "2006/ 1/_2" for "2014/ 4/ 8".
+func spacePaddedLayout(layout, ds, datestr string) string {
+	lt, dt := layoutTokens(layout), layoutTokens(ds)
+	if len(lt) != len(dt) {
+		return layout
+	}
+	var out []string
+	i := 0
+	ot := layoutTokens(datestr)
+	for j := 0; j < len(ot) && i < len(lt); j, i = j+1, i+1 {
+		if ot[j] != " " || j == 0 || (ot[j-1] != "/" && ot[j-1] != "-") || j+1 == len(ot) {
+			out = append(out, lt[i])
+			continue
+		}
+		switch lt[i] {
+		case "01":
+			out = append(out, " 1")
+		case "02":
+			out = append(out, "_2")
+		default:
+			return layout
+		}
+		// the padded digits
+		j++
+	}
+	if i != len(lt) {
+		return layout
+	}
+	return strings.Join(out, "")
+}
 
-	case dateYearDashDash:
-		// 2006-01-02
-		// 2006-1-02
-		// 2006-1-2
-		// 2006-01-2
-		return p, nil
+// goLayoutZone rewrites the trailing Go layout zone of
+// "2014-04-26 15:04:05Z0700" or "...Z07:00" into the Z of UTC.
+func goLayoutZone(datestr string) (string, bool) {
+	for _, token := range []string{"Z0700", "Z07:00"} {
+		if strings.HasSuffix(datestr, token) && len(datestr) > len(token) && unicode.IsDigit(rune(datestr[len(datestr)-len(token)-1])) {
+			return datestr[:len(datestr)-len(token)+1], true
+		}
+	}
+	return datestr, false
+}
 
-	case dateYearDashAlphaDash:
-		// 2013-Feb-03
-		// 2013-Feb-3
-		p.daylen = i - p.dayi
-		p.setDay()
-		return p, nil
+// trimMonthBoundary removes the trailing EOM, end of month, or BOM,
+// beginning of month, of "2014-04 EOM", end being true for EOM.
+func trimMonthBoundary(datestr string) (string, bool, bool) {
+	i := strings.LastIndexByte(datestr, ' ')
+	if i < 1 {
+		return datestr, false, false
+	}
+	switch strings.ToUpper(datestr[i+1:]) {
+	case "EOM":
+		return strings.TrimSpace(datestr[:i]), true, true
+	case "BOM":
+		return strings.TrimSpace(datestr[:i]), false, true
+	}
+	return datestr, false, false
+}
 
-	case dateYearDashDashWs:
-		// 2013-04-01
-		return p, nil
+// dotTime rewrites the dotted time of "2014-04-26T15.30.00Z" into
+// "2014-04-26T15:30:00Z", a third dot being the fraction of a second.
+func dotTime(datestr string) (string, bool) {
+	if len(datestr) < len("2006-01-02T15.04") || datestr[4] != '-' || datestr[7] != '-' ||
+		!hasDigits(datestr, 4) || !hasDigits(datestr[5:], 2) || !hasDigits(datestr[8:], 2) {
+		return datestr, false
+	}
+	if datestr[10] != 'T' && datestr[10] != 't' {
+		return datestr, false
+	}
+	tm := datestr[11:]
+	if !hasDigits(tm, 2) || tm[2] != '.' || !hasDigits(tm[3:], 2) {
+		return datestr, false
+	}
+	ds := []byte(datestr)
+	ds[13] = ':'
+	if len(tm) > 5 && tm[5] == '.' && hasDigits(tm[6:], 2) {
+		ds[16] = ':'
+	}
+	return string(ds), true
+}
 
-	case dateYearDashDashT:
-		return p, nil
+// plusSeparator rewrites the + separator of "2014-04-26+17:24:37" as the
+// space of "2014-04-26 17:24:37", the time needing its seconds so an
+// offset is left alone.
+func plusSeparator(datestr string) (string, bool) {
+	if len(datestr) < len("2006-01-02+15:04:05") || datestr[10] != '+' || datestr[4] != '-' || datestr[7] != '-' ||
+		!hasDigits(datestr, 4) || !hasDigits(datestr[5:], 2) || !hasDigits(datestr[8:], 2) {
+		return datestr, false
+	}
+	tm := datestr[11:]
+	if end := strings.IndexAny(tm, ".,Z+- "); end >= 0 {
+		tm = tm[:end]
+	}
+	if strings.Count(tm, ":") != 2 || !isClock(tm) {
+		return datestr, false
+	}
+	return datestr[:10] + " " + datestr[11:], true
+}
 
-	case dateDigitDashAlphaDash:
-		// 13-Feb-03   ambiguous
-		// 28-Feb-03   ambiguous
-		// 29-Jun-2016
-		length := len(datestr) - (p.moi + p.molen + 1)
-		if length == 4 {
-			p.yearlen = 4
-			p.set(p.yeari, "2006")
-			// We now also know that part1 was the day
-			p.dayi = 0
-			p.daylen = p.part1Len
-			p.setDay()
-		} else if length == 2 {
-			// We have no idea if this is
-			// yy-mon-dd   OR  dd-mon-yy
-			//
-			// We are going to ASSUME (bad, bad) that it is dd-mon-yy  which is a horible assumption
-			p.ambiguousMD = true
-			p.yearlen = 2
-			p.set(p.yeari, "06")
-			// We now also know that part1 was the day
-			p.dayi = 0
-			p.daylen = p.part1Len
-			p.setDay()
+// ordinalFirst rewrites the ISO 8601 ordinal date of "2014-103T09:00:00Z"
+// into the calendar date "2014-04-13T09:00:00Z", the time being optional.
+func ordinalFirst(datestr string) (string, bool, error) {
+	if len(datestr) < len("2014-103") || datestr[4] != '-' || !hasDigits(datestr, 4) || !hasDigits(datestr[5:], 3) {
+		return datestr, false, nil
+	}
+	rest := datestr[8:]
+	if rest != "" && rest[0] != 'T' && rest[0] != 't' && rest[0] != ' ' {
+		return datestr, false, nil
+	}
+	year, _ := strconv.Atoi(datestr[:4])
+	yday, _ := strconv.Atoi(datestr[5:8])
+	t, err := ordinalDate(year, yday)
+	if err != nil {
+		return datestr, false, err
+	}
+	return t.Format("2006-01-02") + rest, true, nil
+}
+
+// clockFirst rewrites the time first "17:24:37, Apr 26 2014" into the
+// date first "Apr 26 2014 17:24:37", the comma after the time optional.
+// Only a Mon dd yyyy date following the time is rewritten.
+func clockFirst(datestr string) (string, bool) {
+	// 17:24:37,Apr 26 2014
+	fields := strings.Fields(strings.Replace(datestr, ",", ", ", 1))
+	if len(fields) < 4 || len(fields) > 5 {
+		return datestr, false
+	}
+	tm, date := fields[:len(fields)-3], fields[len(fields)-3:]
+	last := &tm[len(tm)-1]
+	*last = strings.TrimSuffix(*last, ",")
+	if !isClock(tm[0]) || strings.HasSuffix(*last, ",") {
+		return datestr, false
+	}
+	if len(tm) == 2 {
+		switch strings.ToLower(tm[1]) {
+		case "am", "pm":
+		default:
+			return datestr, false
+		}
+	}
+	if len(date[1]) > 2 || !allDigits(date[1]) || len(date[2]) != 4 || !allDigits(date[2]) {
+		return datestr, false
+	}
+	if _, ok := monthName(date[0]); !ok {
+		return datestr, false
+	}
+	return strings.Join(date, " ") + " " + strings.Join(tm, " "), true
+}
+
+// isClock is true for a hh:mm or hh:mm:ss time, the seconds with an
+// optional fraction, 17:24:37.123.
+func isClock(s string) bool {
+	clock := strings.Split(s, ":")
+	if len(clock) < 2 || len(clock) > 3 {
+		return false
+	}
+	if len(clock) == 3 {
+		if dot := strings.IndexByte(clock[2], '.'); dot >= 0 {
+			if !allDigits(clock[2][dot+1:]) {
+				return false
+			}
+			clock[2] = clock[2][:dot]
+		}
+	}
+	for _, part := range clock {
+		if !allDigits(part) {
+			return false
 		}
+	}
+	return true
+}
+
+// weekTime returns the time of a "Week 15 2014 Monday" date-string, or
+// nil if the date-string is not one.
+func (p *parser) weekTime(datestr string) (*time.Time, error) {
+	fields := strings.Fields(datestr)
+	if len(fields) != 4 || !strings.EqualFold(fields[0], "week") || len(fields[1]) > 2 || !allDigits(fields[1]) ||
+		len(fields[2]) != 4 || !allDigits(fields[2]) {
+		return nil, nil
+	}
+	week, _ := strconv.Atoi(fields[1])
+	year, _ := strconv.Atoi(fields[2])
+	day, ok := weekdayName(fields[3])
+	if !ok {
+		return nil, fmt.Errorf("Unknown weekday %q in %q", fields[3], datestr)
+	}
+	t, err := weekDate(year, week, day, p.weekStart)
+	if err != nil {
+		return nil, err
+	}
+	loc := p.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	return &t, nil
+}
+
+// monthYearTime returns midnight of the first of the month of a
+// "April 2014" or "2014 April" date-string, or nil if the date-string is
+// not one.  Localized names have been translated already.
+func (p *parser) monthYearTime(datestr string) *time.Time {
+	fields := strings.Fields(datestr)
+	if len(fields) != 2 {
+		return nil
+	}
+	name, year := fields[0], fields[1]
+	if hasDigits(name, 4) {
+		name, year = year, name
+	}
+	if !hasDigits(year, 4) || len(year) != 4 {
+		return nil
+	}
+	name = strings.TrimSuffix(name, ".")
+	month, ok := monthName(name)
+	if !ok {
+		return nil
+	}
+	y, _ := strconv.Atoi(year)
+	loc := p.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	// April 2014   January 2006
+	p.format = []byte(strings.Replace(strings.Replace(datestr, name, monthLayout(name), 1), year, "2006", 1))
+	t := time.Date(y, month, 1, 0, 0, 0, 0, loc)
+	return &t
+}
 
-		return p, nil
+// monthLayout is the layout of a month name, Jan for a 3 letter name
+// and January for a full one.
+func monthLayout(name string) string {
+	if len(name) == 3 {
+		return "Jan"
+	}
+	return "January"
+}
 
-	case dateDigitDot:
-		// 2014.05
-		p.molen = i - p.moi
-		p.setMonth()
-		return p, nil
+// monthDayTime returns the time of a "Apr 26 17:24" or "Apr 26 17:24:37"
+// date-string without a year, the year being inferred as ParseSyslog
+// does, or of the "26-Apr 17:24:37" of router logs, the year being the
+// most recent one not after the base date, or nil if the date-string is
+// not one.
+func (p *parser) monthDayTime(datestr string) (*time.Time, error) {
+	fields := strings.Fields(datestr)
+	dayFirst := false
+	if len(fields) == 2 {
+		if i := strings.IndexByte(fields[0], '-'); i > 0 {
+			// 26-Apr 17:24 as Apr 26 17:24
+			fields = []string{fields[0][i+1:], fields[0][:i], fields[1]}
+			dayFirst = true
+		}
+	}
+	if len(fields) != 3 || !isClock(fields[2]) || len(fields[1]) > 2 || !allDigits(fields[1]) {
+		return nil, nil
+	}
+	name := strings.TrimSuffix(fields[0], ".")
+	month, ok := monthName(name)
+	if !ok {
+		return nil, nil
+	}
+	loc := p.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	layout := "15:04"
+	if strings.Count(fields[2], ":") == 2 {
+		layout = "15:04:05"
+	}
+	tm, err := time.Parse(layout, fields[2])
+	if err != nil {
+		return nil, err
+	}
+	// Apr 26 17:24   Jan 2 15:04
+	format := strings.Replace(datestr, name, monthLayout(name), 1)
+	format = strings.Replace(format, fields[1], "2", 1)
+	p.format = []byte(strings.Replace(format, fields[2], layout, 1))
+	day, _ := strconv.Atoi(fields[1])
+	year := yearOf(p.now(), month, day, loc)
+	if dayFirst {
+		// router logs are never ahead of the base date
+		year = pastYearOf(p.now(), month, day, loc)
+	}
+	t := time.Date(year, month, day, tm.Hour(), tm.Minute(), tm.Second(), 0, loc)
+	if t.Day() != day {
+		return nil, fmt.Errorf("Invalid date %q in %d", datestr, year)
+	}
+	return &t, nil
+}
 
-	case dateDigitDotDot:
-		// 03.31.1981
-		// 3.31.2014
-		// 3.2.1981
-		// 3.2.81
-		// 08.21.71
-		// 2018.09.30
-		return p, nil
+// now is the base date, or the time of the clock if there is none.
+func (p *parser) now() time.Time {
+	if !p.baseDate.IsZero() {
+		return p.baseDate
+	}
+	if p.clock != nil {
+		return p.clock.Now()
+	}
+	return nowFunc()
+}
 
-	case dateDigitWsMoYear:
-		// 2 Jan 2018
-		// 2 Jan 18
-		// 2 Jan 2018 23:59
-		// 02 Jan 2018 23:59
-		// 12 Feb 2006, 19:17
-		return p, nil
+// today is midnight of the base date in the parser location, if any.
+func (p *parser) today() time.Time {
+	base := p.now()
+	if p.loc != nil {
+		base = base.In(p.loc)
+	}
+	return time.Date(base.Year(), base.Month(), base.Day(), 0, 0, 0, 0, base.Location())
+}
 
-	case dateDigitWsMolong:
-		// 18 January 2018
-		// 8 January 2018
-		if p.daylen == 2 {
-			p.format = []byte("02 January 2006")
-			return p, nil
+// translate rewrites the date-string from the parser language into
+// a form the english parser understands.
+func (p *parser) translate(datestr string) string {
+	lang, ok := languages[p.lang]
+	if !ok {
+		return datestr
+	}
+	if lang.names != nil {
+		datestr = translateNames(datestr, lang.names)
+	}
+	if lang.rewrite != nil {
+		datestr = lang.rewrite(datestr)
+	}
+	return datestr
+}
+
+// translateNames replaces each word of the date-string found in names.
+func translateNames(datestr string, names map[string]string) string {
+	var out []byte
+	start := -1
+	for i, r := range datestr + " " {
+		if unicode.IsLetter(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
 		}
-		p.format = []byte("2 January 2006")
-		return p, nil // parse("2 January 2006", datestr, loc)
+		if start >= 0 {
+			word := datestr[start:i]
+			if name, ok := names[strings.ToLower(word)]; ok {
+				word = name
+			}
+			out = append(out, word...)
+			start = -1
+		}
+		if i < len(datestr) {
+			out = append(out, string(r)...)
+		}
+	}
+	return string(out)
+}
 
-	case dateAlphaWsMonth:
-		p.yearlen = i - p.yeari
-		p.setYear()
-		return p, nil
+// leadingWeekday is the weekday of the full or 3 letter english name
+// starting the date-string, Monday, 26 April 2014.
+func leadingWeekday(datestr string) (time.Weekday, bool) {
+	fields := strings.Fields(datestr)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	return weekdayName(strings.TrimSuffix(fields[0], ","))
+}
 
-	case dateAlphaWsMonthMore:
-		return p, nil
+// layoutTokens splits s into runs of letters, runs of digits and single
+// other runes, those of a layout lining up with its date-string.
+func layoutTokens(s string) []string {
+	var tokens []string
+	start := 0
+	for i, r := range s {
+		if i > start {
+			prev, _ := utf8.DecodeLastRuneInString(s[:i])
+			letters := unicode.IsLetter(prev) && unicode.IsLetter(r)
+			digits := unicode.IsDigit(prev) && unicode.IsDigit(r)
+			if !letters && !digits {
+				tokens = append(tokens, s[start:i])
+				start = i
+			}
+		}
+	}
+	if start < len(s) {
+		tokens = append(tokens, s[start:])
+	}
+	return tokens
+}
 
-	case dateAlphaWsDigitMoreWs:
-		// oct 1, 1970
-		p.yearlen = i - p.yeari
-		p.setYear()
-		return p, nil
+// renamedLayout maps the layout of ds to one of datestr, ds with words
+// renamed or punctuation added, as Montag, 26. April 2014 is to Monday,
+// 26 April 2014.  A renamed weekday and added punctuation are literal
+// text, a renamed month has no layout, time.Parse only reading english.
+func renamedLayout(layout, ds, datestr string) string {
+	lt, dt := layoutTokens(layout), layoutTokens(ds)
+	if len(lt) != len(dt) {
+		return layout
+	}
+	var out []string
+	i := 0
+	for _, token := range layoutTokens(datestr) {
+		switch {
+		case i < len(dt) && strings.EqualFold(token, dt[i]):
+			out = append(out, lt[i])
+			i++
+		case i < len(dt) && unicode.IsLetter([]rune(token)[0]) && unicode.IsLetter([]rune(dt[i])[0]):
+			if _, ok := weekdayName(dt[i]); !ok {
+				return layout
+			}
+			out = append(out, token)
+			i++
+		default:
+			out = append(out, token)
+		}
+	}
+	if i != len(dt) {
+		return layout
+	}
+	return strings.Join(out, "")
+}
 
-	case dateAlphaWsDigitMoreWsYear:
-		// May 8, 2009 5:57:51 PM
-		// Jun 7, 2005, 05:57:51
-		return p, nil
+// overrideMonthNames replaces each word of the date-string found in names
+// with the english month name, or its abbreviation for a name of up to
+// three letters.  Any other english month name is an error.
+func overrideMonthNames(datestr string, names map[string]time.Month) (string, error) {
+	var out []byte
+	start := -1
+	for i, r := range datestr + " " {
+		if unicode.IsLetter(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			word := datestr[start:i]
+			if month, ok := names[strings.ToLower(word)]; ok {
+				abbrev := utf8.RuneCountInString(word) <= 3
+				if word = month.String(); abbrev {
+					// the abbreviation for an abbreviation, 31-Dec-2014
+					word = word[:3]
+				}
+			} else if _, ok := monthName(word); ok {
+				return datestr, fmt.Errorf("Month %q is not one of the month names in %q", word, datestr)
+			}
+			out = append(out, word...)
+			start = -1
+		}
+		if i < len(datestr) {
+			out = append(out, string(r)...)
+		}
+	}
+	return string(out), nil
+}
 
-	case dateAlphaWsAlpha:
-		return p, nil
+// germanDay drops the period of the german ordinal day, 26. April 2014.
+func germanDay(datestr string) string {
+	for i := 1; i+2 < len(datestr); i++ {
+		if datestr[i] == '.' && datestr[i+1] == ' ' && unicode.IsDigit(rune(datestr[i-1])) &&
+			unicode.IsLetter(rune(datestr[i+2])) {
+			return datestr[:i] + datestr[i+1:]
+		}
+	}
+	return datestr
+}
 
-	case dateAlphaWsAlphaYearmaybe:
-		return p, nil
+// frenchTime rewrites the french hour notation 17h30, 17 h 30 and 17 h
+// as 17:30 and 17:00.
+func frenchTime(datestr string) string {
+	for i := 1; i < len(datestr); i++ {
+		if datestr[i] != 'h' && datestr[i] != 'H' {
+			continue
+		}
+		// 1 or 2 digit hour, optionally followed by a space
+		hourEnd := i
+		if datestr[hourEnd-1] == ' ' {
+			hourEnd--
+		}
+		hourStart := hourEnd
+		for hourStart > 0 && hourEnd-hourStart < 3 && unicode.IsDigit(rune(datestr[hourStart-1])) {
+			hourStart--
+		}
+		if hourStart == hourEnd || hourEnd-hourStart > 2 {
+			continue
+		}
+		if hourStart > 0 && datestr[hourStart-1] != ' ' && datestr[hourStart-1] != 'T' {
+			continue
+		}
+		mini := i + 1
+		if mini < len(datestr) && datestr[mini] == ' ' {
+			mini++
+		}
+		switch {
+		case hasDigits(datestr[mini:], 2):
+			// 17h30   17 h 30
+			return datestr[:hourEnd] + ":" + datestr[mini:]
+		case i+1 == len(datestr) || datestr[i+1] == ' ':
+			// 17 h
+			return datestr[:hourEnd] + ":00" + datestr[i+1:]
+		}
+	}
+	return datestr
+}
 
-	case dateDigitSlash:
-		// 3/1/2014
-		// 10/13/2014
-		// 01/02/2006
-		// 2014/10/13
-		return p, nil
+// zoneName is the alphabetic zone name starting at tzi, if any.
+func (p *parser) zoneName() string {
+	if p.tzi == 0 || p.tzi >= len(p.datestr) {
+		return ""
+	}
+	end := p.tzi
+	for end < len(p.datestr) && unicode.IsLetter(rune(p.datestr[end])) {
+		end++
+	}
+	return p.datestr[p.tzi:end]
+}
 
-	case dateDigitChineseYear:
-		// dateDigitChineseYear
-		//   2014年04月08日
-		p.format = []byte("2006年01月02日")
-		return p, nil
+// knownZone is the wall clock of t in the offset of its well known zone
+// name, when the name was parsed without a numeric offset or Z.  A name the
+// layout parsed as a zone, as MST in the ANSIC Mon Jan _2 15:04:05 MST
+// 2006, and the locationZones are left to time.Parse.
+func (p *parser) knownZone(t time.Time) time.Time {
+	if p.t != nil || p.offseti != 0 || p.stateTime == timeZ {
+		return t
+	}
+	name := strings.ToUpper(p.zoneName())
+	known, ok := zoneOffsets[name]
+	if zone, _ := t.Zone(); !ok || zone == name || locationZones[name] {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.FixedZone(name, known))
+}
 
-	case dateDigitChineseYearWs:
-		p.format = []byte("2006年01月02日 15:04:05")
-		return p, nil
+// setGeneralizedTime sets the layout of an ASN.1 GeneralizedTime, or a
+// UTCTime with a two digit year, whose packed digits end at i.
+func (p *parser) setGeneralizedTime(i int) error {
+	layout := "20060102150405"
+	if i == 12 {
+		layout = "060102150405"
+		p.utcTime = true
+	}
+	rest := p.datestr[i:]
+	if rest[0] == '.' {
+		n := 1
+		for n < len(rest) && rest[n] >= '0' && rest[n] <= '9' {
+			n++
+		}
+		if n == 1 {
+			return unknownErr(p.datestr)
+		}
+		layout += "." + strings.Repeat("0", n-1)
+		rest = rest[n:]
+	}
+	switch {
+	case rest == "":
+	case rest == "Z" || rest == "z":
+		layout += rest
+		p.stateTime = timeZ
+	case len(rest) == 5 && (rest[0] == '+' || rest[0] == '-') && allDigits(rest[1:]):
+		layout += "-0700"
+	default:
+		return unknownErr(p.datestr)
+	}
+	p.format = []byte(layout)
+	return nil
+}
 
-	case dateWeekdayComma:
-		// Monday, 02 Jan 2006 15:04:05 -0700
-		// Monday, 02 Jan 2006 15:04:05 +0100
-		// Monday, 02-Jan-06 15:04:05 MST
-		return p, nil
+// sentinel returns the time of a WithSentinels date-string, nil for any
+// other.
+func (p *parser) sentinel(datestr string) *time.Time {
+	if t, ok := p.sentinels[datestr]; ok {
+		return &t
+	}
+	return nil
+}
 
-	case dateWeekdayAbbrevComma:
-		// Mon, 02-Jan-06 15:04:05 MST
-		// Mon, 02 Jan 2006 15:04:05 MST
-		return p, nil
+// checkRanges returns an error for an hour over 23 or minute or second
+// over 59 in the date-string.
+func (p *parser) checkRanges() error {
+	for _, field := range []struct {
+		name   string
+		i, n   int
+		maxval int
+	}{
+		{"hour", p.houri, p.hourlen, 23},
+		{"minute", p.mini, p.minlen, 59},
+		{"second", p.seci, p.seclen, 59},
+	} {
+		if field.i == 0 || field.n <= 0 || field.i+field.n > len(p.datestr) {
+			continue
+		}
+		if v, err := strconv.Atoi(p.datestr[field.i : field.i+field.n]); err == nil && v > field.maxval {
+			return &ParseError{
+				Input:    p.datestr,
+				Position: field.i,
+				Length:   field.n,
+				Reason:   field.name + " out of range",
+				msg:      fmt.Sprintf("The %s %d is out of range in %q", field.name, v, p.datestr),
+			}
+		}
+	}
+	return nil
+}
+
+// fieldSpan is the position and length of the field named as in the
+// range errors of time.Parse, or -1 if it was not lexed.
+func (p *parser) fieldSpan(field string) (int, int) {
+	var i, n int
+	switch field {
+	case "month":
+		i, n = p.moi, p.molen
+	case "day":
+		i, n = p.dayi, p.daylen
+	case "hour":
+		i, n = p.houri, p.hourlen
+	case "minute":
+		i, n = p.mini, p.minlen
+	case "second":
+		i, n = p.seci, p.seclen
+	}
+	if n <= 0 {
+		return -1, 0
+	}
+	return i, n
+}
 
+// checkOffset rejects the minutes or seconds over 59 of a numeric offset,
+// +00:53:60, which time.Parse carries into the next field.
+func (p *parser) checkOffset() error {
+	if p.offseti == 0 || p.offseti >= len(p.format) {
+		return nil
+	}
+	layout, value := string(p.format[p.offseti:]), p.datestr[p.offseti:]
+	for _, offset := range []string{"-07:00:00", "-070000", "-07:00", "-0700"} {
+		if !strings.HasPrefix(layout, offset) || len(value) < len(offset) {
+			continue
+		}
+		digits := strings.Replace(value[len("-07"):len(offset)], ":", "", -1)
+		for i := 0; i+2 <= len(digits); i += 2 {
+			if n, err := strconv.Atoi(digits[i : i+2]); err == nil && n > 59 {
+				return fmt.Errorf("Offset out of range in %q", p.datestr)
+			}
+		}
+		return nil
 	}
+	return nil
+}
 
-	return nil, unknownErr(datestr)
+// setOffset sets the layout of the numeric offset from offseti up to end,
+// either the hour only -07, the full -0700 or with seconds -070000.
+func (p *parser) setOffset(end int) {
+	switch end - p.offseti {
+	case len("-07"):
+		p.set(p.offseti, "-07")
+	case len("-070000"):
+		// the seconds of a local mean time offset
+		p.set(p.offseti, "-070000")
+	default:
+		p.set(p.offseti, "-0700")
+	}
 }
 
-type parser struct {
-	loc              *time.Location
-	preferMonthFirst bool
-	ambiguousMD      bool
-	stateDate        dateState
-	stateTime        timeState
-	format           []byte
-	datestr          string
-	fullMonth        string
-	skip             int
-	extra            int
-	part1Len         int
-	yeari            int
-	yearlen          int
-	moi              int
-	molen            int
-	dayi             int
-	daylen           int
-	houri            int
-	hourlen          int
-	mini             int
-	minlen           int
-	seci             int
-	seclen           int
-	msi              int
-	mslen            int
-	offseti          int
-	offsetlen        int
-	tzi              int
-	tzlen            int
-	t                *time.Time
-}
-
-func newParser(dateStr string, loc *time.Location) *parser {
-	p := parser{
-		stateDate:        dateStart,
-		stateTime:        timeIgnore,
-		datestr:          dateStr,
-		loc:              loc,
-		preferMonthFirst: true,
+// setOffsetColon sets the layout of the numeric offset with colons from
+// offseti up to end, -07:00 or with seconds -07:00:00.
+func (p *parser) setOffsetColon(end int) {
+	if end-p.offseti == len("-07:00:00") {
+		p.set(p.offseti, "-07:00:00")
+	} else {
+		p.set(p.offseti, "-07:00")
 	}
-	p.format = []byte(dateStr)
-	return &p
+}
+
+// twoDigitYear is true if the layout has the 06 year but not 2006.
+func (p *parser) twoDigitYear() bool {
+	layout := string(p.format)
+	return strings.Contains(layout, "06") && !strings.Contains(layout, "2006")
 }
 
 func (p *parser) nextIs(i int, b byte) bool {
@@ -1729,6 +5193,13 @@ func (p *parser) set(start int, val string) {
 		p.format[start+i] = byte(r)
 	}
 }
+// setZoneName sets the layout of the zone name of tzlen at tzi, upper
+// casing the name as time.Parse only accepts utc as UTC.
+func (p *parser) setZoneName(layout string) {
+	end := p.tzi + p.tzlen
+	p.datestr = p.datestr[:p.tzi] + strings.ToUpper(p.datestr[p.tzi:end]) + p.datestr[end:]
+	p.set(p.tzi, layout)
+}
 func (p *parser) setMonth() {
 	if p.molen == 2 {
 		p.set(p.moi, "01")
@@ -1751,6 +5222,91 @@ func (p *parser) setYear() {
 		p.set(p.yeari, "2006")
 	}
 }
+
+// setFirstPart sets the first field of an ambiguous numeric date, ending
+// at the separator at i, according to the parser field order.
+func (p *parser) setFirstPart(i int) {
+	switch p.order {
+	case OrderDMY:
+		p.daylen = i
+		p.setDay()
+		p.moi = i + 1
+	case OrderYMD:
+		p.yearlen = i
+		p.setYear()
+		p.moi = i + 1
+	default:
+		p.molen = i
+		p.setMonth()
+		p.dayi = i + 1
+	}
+}
+
+// swapsDayMonth is true when an ambiguous date not valid in the field order
+// is read in the other, any order but that of ParseOrder.  Month first the
+// dashes of 29-06-2016 are not read day first.
+func (p *parser) swapsDayMonth() bool {
+	if !p.ambiguousMD || (p.orderSet && !p.orderPreferred) {
+		return false
+	}
+	return p.order == OrderDMY || p.dateSep != '-'
+}
+
+// swapDayMonth swaps the layouts of the numeric month and day of an
+// ambiguous date, to parse it in the other field order.  It is false
+// when they are not both numeric.
+func (p *parser) swapDayMonth() bool {
+	if p.skip > 0 || p.molen < 1 || p.molen > 2 || p.daylen < 1 || p.daylen > 2 ||
+		p.moi+p.molen > len(p.datestr) || p.dayi+p.daylen > len(p.datestr) {
+		return false
+	}
+	if !allDigits(p.datestr[p.moi:p.moi+p.molen]) || !allDigits(p.datestr[p.dayi:p.dayi+p.daylen]) {
+		return false
+	}
+	p.moi, p.dayi = p.dayi, p.moi
+	p.molen, p.daylen = p.daylen, p.molen
+	p.setMonth()
+	p.setDay()
+	return true
+}
+
+// setMonthYear sets a numeric date of two fields, the second a 4 digit
+// year, as month/year 04/2014.  It is false for any other date.
+func (p *parser) setMonthYear() bool {
+	start := p.part1Len + 1
+	end := start
+	for end < len(p.datestr) && p.datestr[end] >= '0' && p.datestr[end] <= '9' {
+		end++
+	}
+	if p.yeari > 0 || p.yearlen > 0 || end-start != 4 || (end < len(p.datestr) && p.datestr[end] != ' ') {
+		return false
+	}
+	p.ambiguousMD = false
+	p.moi, p.molen = 0, p.part1Len
+	p.setMonth()
+	p.dayi, p.daylen = 0, 0
+	p.yeari, p.yearlen = start, 4
+	p.setYear()
+	return true
+}
+
+// setSecondPart sets the middle field of a numeric date ending at the
+// separator at i, and marks where the last field starts.
+func (p *parser) setSecondPart(i int) {
+	if p.moi > 0 && p.molen == 0 {
+		p.molen = i - p.moi
+		p.setMonth()
+		if p.yearlen > 0 {
+			p.dayi = i + 1
+		} else {
+			p.yeari = i + 1
+		}
+	} else if p.daylen == 0 {
+		p.daylen = i - p.dayi
+		p.setDay()
+		p.yeari = i + 1
+	}
+}
 func (p *parser) coalesceDate(end int) {
 	if p.yeari > 0 {
 		if p.yearlen == 0 {
@@ -1816,7 +5372,21 @@ func (p *parser) coalesceTime(end int) {
 func (p *parser) setFullMonth(month string) {
 	if p.moi == 0 {
 		p.format = []byte(fmt.Sprintf("%s%s", "January", p.format[len(month):]))
+	} else {
+		p.format = []byte(fmt.Sprintf("%s%s%s", p.format[:p.moi], "January", p.format[p.moi+len(month):]))
+	}
+}
+
+// setMonthName sets the layout of the month name at moi, either the
+// abbreviated Jan or a full month name.
+func (p *parser) setMonthName() {
+	if p.molen > len("Jan") {
+		if month := strings.ToLower(p.datestr[p.moi : p.moi+p.molen]); isMonthFull(month) {
+			p.fullMonth = month
+			return
+		}
 	}
+	p.set(p.moi, "Jan")
 }
 
 func (p *parser) trimExtra() {
@@ -1838,6 +5408,66 @@ func (p *parser) trimExtra() {
 // }
 
 func (p *parser) parse() (time.Time, error) {
+	if p.pedanticRanges && p.t == nil {
+		if err := p.checkRanges(); err != nil {
+			return time.Time{}, err
+		}
+	}
+	if p.t == nil {
+		if err := p.checkOffset(); err != nil {
+			return time.Time{}, err
+		}
+	}
+	t, err := p.parseLayout()
+	if err != nil && p.swapsDayMonth() && p.swapDayMonth() {
+		// 02/13/2014 is only valid month first, 13/02/2014 day first
+		if swapped, swapErr := p.parseLayout(); swapErr == nil {
+			t, err = swapped, nil
+		} else {
+			// the error is of the date in the field order
+			p.swapDayMonth()
+		}
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	if p.utcTime && t.Year() >= 2050 {
+		// the UTCTime years 50 to 99 are 1950 to 1999
+		t = t.AddDate(-100, 0, 0)
+	}
+	if p.order == OrderDMY {
+		// 26/04/2014 17:24:37 IST is the day first notation of India and
+		// the UK, whose zone names are not known to time.Parse
+		t = p.knownZone(t)
+	}
+	if p.yearless {
+		// 04/26 is in the year of the base date
+		base := p.now()
+		day := t.Day()
+		t = time.Date(base.Year(), t.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+		if t.Day() != day {
+			return time.Time{}, fmt.Errorf("Invalid date %q in %d", p.datestr, base.Year())
+		}
+	}
+	for _, fn := range p.after {
+		if t, err = fn(t); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return t, nil
+}
+
+// layout is the detected layout of the date-string as given, the layout
+// of a rewritten date-string mapped back through each rewrite.
+func (p *parser) layout() string {
+	layout := string(p.format)
+	for i := len(p.relayouts) - 1; i >= 0; i-- {
+		layout = p.relayouts[i](layout)
+	}
+	return layout
+}
+
+func (p *parser) parseLayout() (time.Time, error) {
 	if p.t != nil {
 		return *p.t, nil
 	}
@@ -1845,15 +5475,89 @@ func (p *parser) parse() (time.Time, error) {
 		p.setFullMonth(p.fullMonth)
 	}
 	if p.skip > 0 && len(p.format) > p.skip {
-		p.format = p.format[p.skip:]
-		p.datestr = p.datestr[p.skip:]
+		if _, ok := weekdayName(strings.TrimSuffix(p.datestr[:p.skip], ", ")); ok && p.skip > len("Mon, ") {
+			// Monday, 02 Jan 2006 keeps its weekday in the layout
+			p.format = append([]byte("Monday, "), p.format[p.skip:]...)
+			p.skip = 0
+		} else {
+			p.format = p.format[p.skip:]
+			p.datestr = p.datestr[p.skip:]
+		}
 	}
 	//gou.Debugf("parse %q   AS   %q", p.datestr, string(p.format))
-	if p.loc == nil {
+	if p.loc == nil || p.stateTime == timeZ {
+		// a trailing Z is UTC whatever location was given
 		return time.Parse(string(p.format), p.datestr)
 	}
 	return time.ParseInLocation(string(p.format), p.datestr, p.loc)
 }
+
+// hasDigits is true if s starts with exactly n digits.
+func hasDigits(s string, n int) bool {
+	if len(s) < n {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return len(s) == n || s[n] < '0' || s[n] > '9'
+}
+
+// weekdayName is the weekday of a full or 3 letter english name.
+func weekdayName(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(name, day.String()) || strings.EqualFold(name, day.String()[:3]) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// monthName is the month of a full or 3 letter english name.
+func monthName(name string) (time.Month, bool) {
+	for month := time.January; month <= time.December; month++ {
+		if strings.EqualFold(name, month.String()) || strings.EqualFold(name, month.String()[:3]) {
+			return month, true
+		}
+	}
+	return 0, false
+}
+
+// fractionalEpoch is the time of epoch seconds with a fraction of up to
+// nine digits, 1384216367.111 millis, 1384216367.111222 micros as
+// logged by python or 1384216367.111222333 nanos.
+func fractionalEpoch(datestr string) (time.Time, bool) {
+	i := len("1332151919")
+	if len(datestr) <= i+1 || len(datestr) > i+1+9 || datestr[i] != '.' {
+		return time.Time{}, false
+	}
+	if !allDigits(datestr[:i]) || !allDigits(datestr[i+1:]) {
+		return time.Time{}, false
+	}
+	secs, err := strconv.ParseInt(datestr[:i], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	fraction := datestr[i+1:] + strings.Repeat("0", 9-len(datestr[i+1:]))
+	nanos, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(secs, nanos), true
+}
+
+// allDigits is true if s is not empty and only ascii digits.
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
 func isMonthFull(alpha string) bool {
 	for _, month := range months {
 		if alpha == month {
@@ -0,0 +1,99 @@
+package dateparse
+
+import (
+	"strings"
+	"testing"
+)
+
+/*
+
+go test -bench Family -run XXX
+
+Each benchmark parses the testInputs of one format family, so a change
+to the lexer or a fast path can be measured per family.
+
+*/
+
+// familyInputs are the testInputs without a location that match.
+func familyInputs(match func(string) bool) []string {
+	var inputs []string
+	for _, th := range testInputs {
+		if th.loc == "" && !th.err && match(th.in) {
+			inputs = append(inputs, th.in)
+		}
+	}
+	return inputs
+}
+
+func benchmarkFamily(b *testing.B, match func(string) bool) {
+	inputs := familyInputs(match)
+	if len(inputs) == 0 {
+		b.Fatal("no testInputs in the family")
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, in := range inputs {
+			ParseAny(in)
+		}
+	}
+}
+
+// 2009-08-12T22:15:09-07:00
+func isRFC3339(s string) bool {
+	return len(s) >= 19 && hasDigits(s, 4) && s[4] == '-' && s[7] == '-' && s[10] == 'T'
+}
+
+// 03/19/2012 10:11:59
+func isSlashMDY(s string) bool {
+	i := strings.IndexByte(s, '/')
+	return (i == 1 || i == 2) && allDigits(s[:i])
+}
+
+// 2014-04-26 17:24:37
+func isDashYMD(s string) bool {
+	return len(s) >= 10 && hasDigits(s, 4) && s[4] == '-' && s[7] == '-' && (len(s) == 10 || s[10] == ' ')
+}
+
+// 1332151919
+func isEpoch(s string) bool {
+	return len(s) >= 10 && allDigits(s)
+}
+
+// Mon, 02 Jan 2006 15:04:05 MST
+func isRFC1123(s string) bool {
+	if len(s) < 5 || s[3] != ',' || s[4] != ' ' {
+		return false
+	}
+	_, ok := weekdayName(s[:3])
+	return ok
+}
+
+// 2014年04月08日
+func isCJK(s string) bool {
+	return strings.ContainsRune(s, '年')
+}
+
+func BenchmarkFamilyRFC3339(b *testing.B) {
+	benchmarkFamily(b, isRFC3339)
+}
+
+func BenchmarkFamilySlashMDY(b *testing.B) {
+	benchmarkFamily(b, isSlashMDY)
+}
+
+func BenchmarkFamilyDashYMD(b *testing.B) {
+	benchmarkFamily(b, isDashYMD)
+}
+
+func BenchmarkFamilyEpoch(b *testing.B) {
+	benchmarkFamily(b, isEpoch)
+}
+
+func BenchmarkFamilyRFC1123(b *testing.B) {
+	benchmarkFamily(b, isRFC1123)
+}
+
+func BenchmarkFamilyCJK(b *testing.B) {
+	benchmarkFamily(b, isCJK)
+}
@@ -1,7 +1,9 @@
 package dateparse
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -44,6 +46,11 @@ var testInputs = []dateTest{
 	{in: "Mon Jan  2 15:04:05 2006", out: "2006-01-02 15:04:05 +0000 UTC"},
 	{in: "Thu May 8 17:57:51 2009", out: "2009-05-08 17:57:51 +0000 UTC"},
 	{in: "Thu May  8 17:57:51 2009", out: "2009-05-08 17:57:51 +0000 UTC"},
+	// a redundant UTC zone after the year
+	{in: "Sat Jul 5 16:28:13 2017 GMT", out: "2017-07-05 16:28:13 +0000 UTC"},
+	{in: "Sat Jul  5 16:28:13 2017 UTC", out: "2017-07-05 16:28:13 +0000 UTC"},
+	{in: "Sat Jul 05 16:28:13 2017 utc", out: "2017-07-05 16:28:13 +0000 UTC"},
+	{in: "Sat Jul 5 16:28:13 2017 GMT", out: "2017-07-05 16:28:13 +0000 UTC", loc: "America/Denver"},
 	// RubyDate    = "Mon Jan 02 15:04:05 -0700 2006"
 	{in: "Mon Jan 02 15:04:05 -0700 2006", out: "2006-01-02 22:04:05 +0000 UTC"},
 	{in: "Thu May 08 11:57:51 -0700 2009", out: "2009-05-08 18:57:51 +0000 UTC"},
@@ -54,6 +61,10 @@ var testInputs = []dateTest{
 	{in: "Thu May 08 17:57:51 PST 2009", out: "2009-05-08 17:57:51 +0000 UTC"},
 	{in: "Thu May 08 17:57:51 CEST 2009", out: "2009-05-08 17:57:51 +0000 UTC"},
 	{in: "Thu May 08 05:05:07 PST 2009", out: "2009-05-08 05:05:07 +0000 UTC"},
+	{in: "thu may  8 17:57:51 utc 2009", out: "2009-05-08 17:57:51 +0000 UTC"},
+	{in: "THU MAY  8 17:57:51 UTC 2009", out: "2009-05-08 17:57:51 +0000 UTC"},
+	{in: "thu may 08 17:57:51 mst 2009", out: "2009-05-08 17:57:51 +0000 UTC"},
+	{in: "fri jul 03 2015 18:04:07 gmt+0100 (gmt daylight time)", out: "2015-07-03 17:04:07 +0000 UTC"},
 	{in: "Thu May 08 5:5:7 PST 2009", out: "2009-05-08 05:05:07 +0000 UTC"},
 	// Day Month dd time
 	{in: "Mon Aug 10 15:44:11 UTC+0000 2015", out: "2015-08-10 15:44:11 +0000 UTC"},
@@ -65,6 +76,14 @@ var testInputs = []dateTest{
 	{in: "Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)", out: "2015-07-03 17:04:07 +0000 UTC"},
 	{in: "Fri Jul 3 2015 06:04:07 GMT+0100 (GMT Daylight Time)", out: "2015-07-03 05:04:07 +0000 UTC"},
 	{in: "Fri Jul 3 2015 06:04:07 PST-0700 (Pacific Daylight Time)", out: "2015-07-03 13:04:07 +0000 UTC"},
+	{in: "Fri Jul 03 2015 18:04:07 GMT+0100", out: "2015-07-03 17:04:07 +0000 UTC"},
+	// javascript toDateString and a time, no offset
+	{in: "Sat Apr 26 2014 17:24:37", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "Sat Apr 26 2014 17:24:37", out: "2014-04-26 23:24:37 +0000 UTC", loc: "America/Denver"},
+	{in: "Sat Apr 26 2014 17:24", out: "2014-04-26 17:24:00 +0000 UTC"},
+	{in: "Sun Apr 6 2014 7:24:37", out: "2014-04-06 07:24:37 +0000 UTC"},
+	{in: "Sat Apr 26 2014 17:24:37.123", out: "2014-04-26 17:24:37.123 +0000 UTC"},
+	{in: "Sat Apr 26 2014", out: "2014-04-26 00:00:00 +0000 UTC"},
 	// Month dd, yyyy at time
 	{in: "September 17, 2012 at 5:00pm UTC-05", out: "2012-09-17 17:00:00 +0000 UTC"},
 	{in: "September 17, 2012 at 10:09am PST-08", out: "2012-09-17 18:09:00 +0000 UTC"},
@@ -117,10 +136,34 @@ var testInputs = []dateTest{
 	{in: "Thu, 3 Jul 2017 08:08:04 +0100", out: "2017-07-03 07:08:04 +0000 UTC"},
 	{in: "Thu, 03 Jul 2017 8:08:04 +0100", out: "2017-07-03 07:08:04 +0000 UTC"},
 	{in: "Thu, 03 Jul 2017 8:8:4 +0100", out: "2017-07-03 07:08:04 +0000 UTC"},
+	// day, dd Mon yy hh:mm:ss -0700  obsolete RFC822 two digit year with weekday
+	{in: "Mon, 02 Jan 06 15:04:05 -0700", out: "2006-01-02 22:04:05 +0000 UTC"},
+	{in: "Mon, 02 Jan 06 15:04 -0700", out: "2006-01-02 22:04:00 +0000 UTC"},
+	{in: "Mon, 2 Jan 06 15:04 +0100", out: "2006-01-02 14:04:00 +0000 UTC"},
+	{in: "Thu, 01 Jan 70 00:00:00 +0000", out: "1970-01-01 00:00:00 +0000 UTC"},
+	{in: "Wed, 02 Jan 69 10:00:00 +0000", out: "1969-01-02 10:00:00 +0000 UTC"},
+	{in: "Mon, 02 Jan 68 10:00:00 +0000", out: "2068-01-02 10:00:00 +0000 UTC"},
 	//
 	{in: "Tue, 11 Jul 2017 04:08:03 +0200 (CEST)", out: "2017-07-11 02:08:03 +0000 UTC"},
 	{in: "Tue, 5 Jul 2017 04:08:03 -0700 (CEST)", out: "2017-07-05 11:08:03 +0000 UTC"},
 	{in: "Tue, 11 Jul 2017 04:08:03 +0200 (CEST)", out: "2017-07-11 02:08:03 +0000 UTC", loc: "Europe/Berlin"},
+	// comments holding an offset of their own
+	{in: "Tue, 11 Jul 2017 04:08:03 +0100 (UTC+01:00)", out: "2017-07-11 03:08:03 +0000 UTC"},
+	{in: "Tue, 11 Jul 2017 04:08:03 -0500 (GMT-0500)", out: "2017-07-11 09:08:03 +0000 UTC"},
+	{in: "Tue, 11 Jul 2017 04:08:03 -0500 (GMT-0500)", out: "2017-07-11 09:08:03 +0000 UTC", loc: "America/Denver"},
+	{in: "2017-07-11 04:08:03 +0100 (UTC+01:00)", out: "2017-07-11 03:08:03 +0000 UTC"},
+	{in: "2017-07-11 04:08:03.123 -05:00 (GMT-0500)", out: "2017-07-11 09:08:03.123 +0000 UTC"},
+	{in: "2017-07-11T04:08:03+01:00 (UTC+01:00)", out: "2017-07-11 03:08:03 +0000 UTC"},
+	// offsets with seconds, local mean time before standard zones
+	{in: "1884-01-01T00:00:00+00:53:28", out: "1883-12-31 23:06:32 +0000 UTC"},
+	{in: "1884-01-01T00:00:00-00:53:28", out: "1884-01-01 00:53:28 +0000 UTC"},
+	{in: "1884-01-01T00:00:00.5-00:53:28", out: "1884-01-01 00:53:28.5 +0000 UTC"},
+	{in: "1884-01-01T00:00:00+005328", out: "1883-12-31 23:06:32 +0000 UTC"},
+	{in: "1884-01-01 00:00:00 +00:53:28", out: "1883-12-31 23:06:32 +0000 UTC"},
+	{in: "1884-01-01 00:00:00.123 +00:53:28", out: "1883-12-31 23:06:32.123 +0000 UTC"},
+	{in: "1884-01-01 00:00:00 +00:53:28 LMT", out: "1883-12-31 23:06:32 +0000 UTC"},
+	{in: "2017-07-11T04:08:03Z (UTC+01:00)", out: "2017-07-11 04:08:03 +0000 UTC"},
+	{in: "Fri Jul 03 2015 18:04:07 GMT+0100 (UTC+01:00)", out: "2015-07-03 17:04:07 +0000 UTC"},
 	// day, dd-Mon-yy hh:mm:zz TZ
 	{in: "Fri, 03-Jul-15 08:08:08 MST", out: "2015-07-03 08:08:08 +0000 UTC"},
 	{in: "Fri, 03-Jul-15 08:08:08 PST", out: "2015-07-03 15:08:08 +0000 UTC", loc: "America/Los_Angeles"},
@@ -128,6 +171,18 @@ var testInputs = []dateTest{
 	{in: "Fri, 3-Jul-15 08:08:08 MST", out: "2015-07-03 08:08:08 +0000 UTC"},
 	{in: "Fri, 03-Jul-15 8:08:08 MST", out: "2015-07-03 08:08:08 +0000 UTC"},
 	{in: "Fri, 03-Jul-15 8:8:8 MST", out: "2015-07-03 08:08:08 +0000 UTC"},
+	// cookie expires, any case
+	{in: "Sat, 26-Apr-2014 17:24:37 GMT", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "sat, 26-apr-2014 17:24:37 gmt", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "SAT, 26-APR-2014 17:24:37 GMT", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "saturday, 26-apr-14 17:24:37 gmt", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "SATURDAY, 26-APR-14 17:24:37 GMT", out: "2014-04-26 17:24:37 +0000 UTC"},
+	// RFC1123, any case
+	{in: "sat, 26 apr 2014 17:24:37 gmt", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "SAT, 26 APR 2014 17:24:37 GMT", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "sat, 26 apr 2014 17:24:37 utc", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "sat, 26 apr 2014 17:24:37 -0700", out: "2014-04-27 00:24:37 +0000 UTC"},
+	{in: "SAT, 26 APR 2014 17:24:37 -0700", out: "2014-04-27 00:24:37 +0000 UTC"},
 	// RFC850    = "Monday, 02-Jan-06 15:04:05 MST"
 	{in: "Wednesday, 07-May-09 08:00:43 MST", out: "2009-05-07 08:00:43 +0000 UTC"},
 	{in: "Wednesday, 28-Feb-18 09:01:00 MST", out: "2018-02-28 09:01:00 +0000 UTC"},
@@ -136,6 +191,9 @@ var testInputs = []dateTest{
 	{in: "Monday, 02 Jan 2006 15:04:05 +0100", out: "2006-01-02 14:04:05 +0000 UTC"},
 	{in: "Wednesday, 28 Feb 2018 09:01:00 -0300", out: "2018-02-28 12:01:00 +0000 UTC"},
 	{in: "Wednesday, 2 Feb 2018 09:01:00 -0300", out: "2018-02-02 12:01:00 +0000 UTC"},
+	{in: "Saturday, 26 April 2014", out: "2014-04-26 00:00:00 +0000 UTC"},
+	{in: "Saturday, 26 April 2014 17:24:37 -0300", out: "2014-04-26 20:24:37 +0000 UTC"},
+	{in: "Sat, 26 April 2014 17:24:37 +0000", out: "2014-04-26 17:24:37 +0000 UTC"},
 	{in: "Wednesday, 2 Feb 2018 9:01:00 -0300", out: "2018-02-02 12:01:00 +0000 UTC"},
 	{in: "Wednesday, 2 Feb 2018 09:1:00 -0300", out: "2018-02-02 12:01:00 +0000 UTC"},
 	//  dd mon yyyy  12 Feb 2006, 19:17:08
@@ -156,14 +214,50 @@ var testInputs = []dateTest{
 	{in: "07-Feb-2004 09:07:07 +0100", out: "2004-02-07 08:07:07 +0000 UTC"},
 	//  dd-mon-yy   12-Feb-2006 19:17:08
 	{in: "07-Feb-04 09:07:07 +0100", out: "2004-02-07 08:07:07 +0000 UTC"},
+	//  ddMonyyyy:hh:mm:ss   26Apr2014:17:24:37 +0100
+	{in: "26Apr2014:17:24:37 +0100", out: "2014-04-26 16:24:37 +0000 UTC"},
+	{in: "26Apr2014:17:24:37 -0700", out: "2014-04-27 00:24:37 +0000 UTC"},
+	{in: "26Apr2014:17:24:37 +0100", out: "2014-04-26 16:24:37 +0000 UTC", loc: "America/Denver"},
+	{in: "26Apr2014:17:24:37", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "26Apr2014:17:24:37", out: "2014-04-26 23:24:37 +0000 UTC", loc: "America/Denver"},
+	{in: "6Apr2014:7:24:37", out: "2014-04-06 07:24:37 +0000 UTC"},
+	{in: "26Apr2014", out: "2014-04-26 00:00:00 +0000 UTC"},
+	//  hh:mm:ss, Mon dd yyyy   17:24:37, Apr 26 2014
+	{in: "17:24:37, Apr 26 2014", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "17:24:37, Apr 26 2014", out: "2014-04-26 23:24:37 +0000 UTC", loc: "America/Denver"},
+	{in: "17:24:37,Apr 26 2014", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "17:24, Apr 26 2014", out: "2014-04-26 17:24:00 +0000 UTC"},
+	{in: "7:24, Apr 6 2014", out: "2014-04-06 07:24:00 +0000 UTC"},
+	{in: "5:24 PM, Apr 26 2014", out: "2014-04-26 17:24:00 +0000 UTC"},
+	{in: "17:24:37 Apr 26 2014", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "17:24:37.123, Apr 26 2014", out: "2014-04-26 17:24:37.123 +0000 UTC"},
+	{in: "5:24 pm April 26 2014", out: "2014-04-26 17:24:00 +0000 UTC"},
 	// yyyy-mon-dd    2013-Feb-03
 	{in: "2013-Feb-03", out: "2013-02-03 00:00:00 +0000 UTC"},
 	// 03 February 2013
 	{in: "03 February 2013", out: "2013-02-03 00:00:00 +0000 UTC"},
+	// dd Month, yyyy   dd, Month yyyy
+	{in: "26 April, 2014", out: "2014-04-26 00:00:00 +0000 UTC"},
+	{in: "26 April, 2014", out: "2014-04-26 06:00:00 +0000 UTC", loc: "America/Denver"},
+	{in: "6 April, 2014", out: "2014-04-06 00:00:00 +0000 UTC"},
+	{in: "26 Apr, 2014", out: "2014-04-26 00:00:00 +0000 UTC"},
+	{in: "26 Apr, 2014 17:24:37", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "26, April 2014", out: "2014-04-26 00:00:00 +0000 UTC"},
+	{in: "26, Apr 2014", out: "2014-04-26 00:00:00 +0000 UTC"},
+	// Month yyyy   yyyy Month
+	{in: "April 2014", out: "2014-04-01 00:00:00 +0000 UTC"},
+	{in: "April 2014", out: "2014-04-01 06:00:00 +0000 UTC", loc: "America/Denver"},
+	{in: "2014 April", out: "2014-04-01 00:00:00 +0000 UTC"},
+	{in: "Apr 2014", out: "2014-04-01 00:00:00 +0000 UTC"},
+	{in: "2014 dec", out: "2014-12-01 00:00:00 +0000 UTC"},
 	{in: "3 February 2013", out: "2013-02-03 00:00:00 +0000 UTC"},
 	// Chinese 2014年04月18日
 	{in: "2014年04月08日", out: "2014-04-08 00:00:00 +0000 UTC"},
 	{in: "2014年04月08日 19:17:22", out: "2014-04-08 19:17:22 +0000 UTC"},
+	{in: "2014年04月08日 19时17分22秒", out: "2014-04-08 19:17:22 +0000 UTC"},
+	{in: "2014年04月08日 19时17分22秒", out: "2014-04-09 01:17:22 +0000 UTC", loc: "America/Denver"},
+	{in: "2014年04月08日 19时17分", out: "2014-04-08 19:17:00 +0000 UTC"},
+	{in: "2014年04月08日 09时07分", out: "2014-04-08 09:07:00 +0000 UTC"},
 	//  mm/dd/yyyy
 	{in: "03/31/2014", out: "2014-03-31 00:00:00 +0000 UTC"},
 	{in: "3/31/2014", out: "2014-03-31 00:00:00 +0000 UTC"},
@@ -190,6 +284,13 @@ var testInputs = []dateTest{
 	{in: "04/02/2014 4:8 PM", out: "2014-04-02 16:08:00 +0000 UTC"},
 	{in: "04/02/2014 04:08:09.123 AM", out: "2014-04-02 04:08:09.123 +0000 UTC"},
 	{in: "04/02/2014 04:08:09.123 PM", out: "2014-04-02 16:08:09.123 +0000 UTC"},
+	//   yyyy/mm  mm/yyyy
+	{in: "2014/04", out: "2014-04-01 00:00:00 +0000 UTC"},
+	{in: "2014/4", out: "2014-04-01 00:00:00 +0000 UTC"},
+	{in: "04/2014", out: "2014-04-01 00:00:00 +0000 UTC"},
+	{in: "4/2014", out: "2014-04-01 00:00:00 +0000 UTC"},
+	{in: "04/2014 17:24", out: "2014-04-01 17:24:00 +0000 UTC"},
+	{in: "04-2014", out: "2014-04-01 00:00:00 +0000 UTC"},
 	//   yyyy/mm/dd
 	{in: "2014/04/02", out: "2014-04-02 00:00:00 +0000 UTC"},
 	{in: "2014/03/31", out: "2014-03-31 00:00:00 +0000 UTC"},
@@ -250,6 +351,22 @@ var testInputs = []dateTest{
 	{in: "2014-04-26 17:24:37.1 +0000", out: "2014-04-26 17:24:37.1 +0000 UTC"},
 	{in: "2014-05-11 08:20:13 +0000", out: "2014-05-11 08:20:13 +0000 UTC"},
 	{in: "2014-05-11 08:20:13 +0530", out: "2014-05-11 02:50:13 +0000 UTC"},
+	//   yyyy-mm-dd hh:mm:ss +00  hour only offset
+	{in: "2014-04-26 17:24:37 +08", out: "2014-04-26 09:24:37 +0000 UTC"},
+	{in: "2014-04-26 17:24:37 -05", out: "2014-04-26 22:24:37 +0000 UTC"},
+	{in: "2014-04-26 17:24:37 +08", out: "2014-04-26 09:24:37 +0000 UTC", loc: "America/Denver"},
+	{in: "2014-04-26 17:24 +08", out: "2014-04-26 09:24:00 +0000 UTC"},
+	{in: "2014-04-26 17:24:37.123 +08", out: "2014-04-26 09:24:37.123 +0000 UTC"},
+	{in: "2014-04-26 17:24:37 +08 UTC", out: "2014-04-26 09:24:37 +0000 UTC"},
+	// the Go native format in a zone with, or without, an abbreviation
+	{in: "2014-04-26 10:24:37 -07", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "2014-04-26 10:24:37 -07 PDT", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "2014-04-26 10:24:37 -07 PDT", out: "2014-04-26 17:24:37 +0000 UTC", loc: "America/Denver"},
+	{in: "2014-04-26 10:24:37.123456789 -07 PDT", out: "2014-04-26 17:24:37.123456789 +0000 UTC"},
+	{in: "2014-04-26 10:24:37 +05 +05", out: "2014-04-26 05:24:37 +0000 UTC"},
+	{in: "2014-04-26 10:24:37 -07 -07 m=+0.000000001", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "2014-04-26 17:24:37 +0800", out: "2014-04-26 09:24:37 +0000 UTC"},
+	{in: "2014-04-26 17:24:37 +08:00", out: "2014-04-26 09:24:37 +0000 UTC"},
 	//   yyyy-mm-dd hh:mm:ss +0300 +03  ?? issue author said this is from golang?
 	{in: "2018-06-29 19:09:57.77297118 +0300 +03", out: "2018-06-29 16:09:57.77297118 +0000 UTC"},
 	{in: "2018-06-29 19:09:57.77297118 +0300 +0300", out: "2018-06-29 16:09:57.77297118 +0000 UTC"},
@@ -312,6 +429,7 @@ var testInputs = []dateTest{
 	{in: "2012-08-03 18:31:59.000+00:00 PST", out: "2012-08-03 18:31:59 +0000 UTC", loc: "America/Los_Angeles"},
 	//   yyyy-mm-dd hh:mm:ss +00:00 TZ
 	{in: "2012-08-03 18:31:59 +00:00 UTC", out: "2012-08-03 18:31:59 +0000 UTC"},
+	{in: "2015-02-08 03:02:00 +03:00 MSK", out: "2015-02-08 00:02:00 +0000 UTC"},
 	{in: "2012-08-03 13:31:51 -07:00 MST", out: "2012-08-03 20:31:51 +0000 UTC", loc: "America/Denver"},
 	{in: "2012-08-03 18:31:59.257000000 +00:00 UTC", out: "2012-08-03 18:31:59.257 +0000 UTC"},
 	{in: "2012-08-03 13:31:51.123 -08:00 PST", out: "2012-08-03 21:31:51.123 +0000 UTC", loc: "America/Los_Angeles"},
@@ -322,6 +440,15 @@ var testInputs = []dateTest{
 	{in: "2014-04-26 17:24:37.123456 +00:00 UTC", out: "2014-04-26 17:24:37.123456 +0000 UTC"},
 	{in: "2014-04-26 17:24:37.12 +00:00 UTC", out: "2014-04-26 17:24:37.12 +0000 UTC"},
 	{in: "2014-04-26 17:24:37.1 +00:00 UTC", out: "2014-04-26 17:24:37.1 +0000 UTC"},
+	//   yyyy-mm-ddThh  yyyy-mm-ddThh:mm  truncated times
+	{in: "2014-04-26T17", out: "2014-04-26 17:00:00 +0000 UTC"},
+	{in: "2014-04-26T17Z", out: "2014-04-26 17:00:00 +0000 UTC"},
+	{in: "2014-04-26T17+01:00", out: "2014-04-26 16:00:00 +0000 UTC"},
+	{in: "2014-04-26T17-0700", out: "2014-04-27 00:00:00 +0000 UTC"},
+	{in: "2014-04-26T17", out: "2014-04-26 23:00:00 +0000 UTC", loc: "America/Denver"},
+	{in: "2014-04-26T17Z", out: "2014-04-26 17:00:00 +0000 UTC", loc: "America/Denver"},
+	{in: "2014-04-26T17:24", out: "2014-04-26 17:24:00 +0000 UTC"},
+	{in: "2014-04-26T17:24+01:00", out: "2014-04-26 16:24:00 +0000 UTC"},
 	//   yyyy-mm-ddThh:mm:ss
 	{in: "2009-08-12T22:15:09", out: "2009-08-12 22:15:09 +0000 UTC"},
 	{in: "2009-08-08T02:08:08", out: "2009-08-08 02:08:08 +0000 UTC"},
@@ -331,6 +458,20 @@ var testInputs = []dateTest{
 	{in: "2009-08-12T22:15:09.12", out: "2009-08-12 22:15:09.12 +0000 UTC"},
 	{in: "2009-08-12T22:15:09.1", out: "2009-08-12 22:15:09.1 +0000 UTC"},
 	{in: "2014-04-26 17:24:37.3186369", out: "2014-04-26 17:24:37.3186369 +0000 UTC"},
+	//   yyyy-mm-ddThh:mm:ss.fffffff-07:00  .NET round-trip "o"
+	{in: "2014-04-26T17:24:37.1234567+01:00", out: "2014-04-26 16:24:37.1234567 +0000 UTC"},
+	{in: "2014-04-26T17:24:37.1234567-07:00", out: "2014-04-27 00:24:37.1234567 +0000 UTC"},
+	{in: "2014-04-26T17:24:37.1234567Z", out: "2014-04-26 17:24:37.1234567 +0000 UTC"},
+	{in: "2014-04-26T17:24:37.0000001Z", out: "2014-04-26 17:24:37.0000001 +0000 UTC"},
+	{in: "2014-04-26T17:24:37.1234567", out: "2014-04-26 17:24:37.1234567 +0000 UTC"},
+	{in: "2014-04-26 17:24:37.1234567 +01:00", out: "2014-04-26 16:24:37.1234567 +0000 UTC"},
+	{in: "2014-04-26 17:24:37.123 +01:00", out: "2014-04-26 16:24:37.123 +0000 UTC"},
+	//   lower case t, comma fraction and an offset without colon together
+	{in: "2014-04-26t17:24:37,123456+0100", out: "2014-04-26 16:24:37.123456 +0000 UTC"},
+	{in: "2014-04-26t17:24:37,123456+0100", out: "2014-04-26 16:24:37.123456 +0000 UTC", loc: "America/Denver"},
+	{in: "2014-04-26t17:24:37,123456-0700", out: "2014-04-27 00:24:37.123456 +0000 UTC"},
+	{in: "2014-04-26t17:24:37,123+01:00", out: "2014-04-26 16:24:37.123 +0000 UTC"},
+	{in: "2014-04-26t17:24:37,123456z", out: "2014-04-26 17:24:37.123456 +0000 UTC"},
 	//   yyyy-mm-ddThh:mm:ss-07:00
 	{in: "2009-08-12T22:15:09-07:00", out: "2009-08-13 05:15:09 +0000 UTC"},
 	{in: "2009-08-12T22:15:09-03:00", out: "2009-08-13 01:15:09 +0000 UTC"},
@@ -338,6 +479,11 @@ var testInputs = []dateTest{
 	{in: "2009-08-12T22:15:09.123-07:00", out: "2009-08-13 05:15:09.123 +0000 UTC"},
 	{in: "2016-06-21T19:55:00+01:00", out: "2016-06-21 18:55:00 +0000 UTC"},
 	{in: "2016-06-21T19:55:00.799+01:00", out: "2016-06-21 18:55:00.799 +0000 UTC"},
+	//   yyyy-mm-ddThh:mm:ss-07:00 MST  zone name after the offset
+	{in: "2016-06-21T19:55:00+01:00 BST", out: "2016-06-21 18:55:00 +0000 UTC"},
+	{in: "2016-06-21T19:55:00.799+01:00 BST", out: "2016-06-21 18:55:00.799 +0000 UTC"},
+	{in: "2016-06-21T19:55:00+0100 BST", out: "2016-06-21 18:55:00 +0000 UTC"},
+	{in: "2016-06-21T19:55:00+01:00 BST", out: "2016-06-21 18:55:00 +0000 UTC", loc: "America/Denver"},
 	//   yyyy-mm-ddThh:mm:ss-0700
 	{in: "2009-08-12T22:15:09-0700", out: "2009-08-13 05:15:09 +0000 UTC"},
 	{in: "2009-08-12T22:15:09-0300", out: "2009-08-13 01:15:09 +0000 UTC"},
@@ -357,20 +503,105 @@ var testInputs = []dateTest{
 	{in: "2009-08-12T22:15:09.9999Z", out: "2009-08-12 22:15:09.9999 +0000 UTC"},
 	{in: "2009-08-12T22:15:09.99999999Z", out: "2009-08-12 22:15:09.99999999 +0000 UTC"},
 	{in: "2009-08-12T22:15:9.99999999Z", out: "2009-08-12 22:15:09.99999999 +0000 UTC"},
+	//   yyyy-mm-dd hh:mm:ssZ  space instead of T
+	{in: "2009-08-12 22:15Z", out: "2009-08-12 22:15:00 +0000 UTC"},
+	{in: "2009-08-12 22:15:09Z", out: "2009-08-12 22:15:09 +0000 UTC"},
+	{in: "2009-08-12 22:15:09.123Z", out: "2009-08-12 22:15:09.123 +0000 UTC"},
+	{in: "2009-08-12 22:15:09.99999999Z", out: "2009-08-12 22:15:09.99999999 +0000 UTC"},
+	{in: "2009-08-12 22:15:09Z", out: "2009-08-12 22:15:09 +0000 UTC", loc: "America/Denver"},
+	{in: "2009-08-12T22:15:09Z", out: "2009-08-12 22:15:09 +0000 UTC", loc: "America/Denver"},
+	{in: "2009-08-12T22:15:09.123Z", out: "2009-08-12 22:15:09.123 +0000 UTC", loc: "America/Denver"},
+	{in: "2009-08-12T22:15:09Z UTC", out: "2009-08-12 22:15:09 +0000 UTC"},
+	{in: "2009-08-12T22:15:09.123Z UTC", out: "2009-08-12 22:15:09.123 +0000 UTC"},
+	{in: "2009-08-12T22:15:09Z UTC", out: "2009-08-12 22:15:09 +0000 UTC", loc: "America/Denver"},
+	//   yyyy-mm-ddthh:mm:ssz  lower case t and z
+	{in: "2009-08-12t22:15:09", out: "2009-08-12 22:15:09 +0000 UTC"},
+	{in: "2009-08-12T22:15:09z", out: "2009-08-12 22:15:09 +0000 UTC"},
+	{in: "2009-08-12t22:15:09Z", out: "2009-08-12 22:15:09 +0000 UTC"},
+	{in: "2009-08-12t22:15:09z", out: "2009-08-12 22:15:09 +0000 UTC"},
+	{in: "2009-08-12t22:15:09.123z", out: "2009-08-12 22:15:09.123 +0000 UTC"},
+	{in: "2009-08-12t22:15:09-07:00", out: "2009-08-13 05:15:09 +0000 UTC"},
+	{in: "2009-08-12t22:15:09z", out: "2009-08-12 22:15:09 +0000 UTC", loc: "America/Denver"},
+	//   yyyy-mm-dd hh:mm:ss-07:00  space instead of T
+	{in: "2009-08-12 22:15:09-07:00", out: "2009-08-13 05:15:09 +0000 UTC"},
+	{in: "2009-08-12 22:15:09.123-07:00", out: "2009-08-13 05:15:09.123 +0000 UTC"},
+	{in: "2009-08-12 22:15:09-0700", out: "2009-08-13 05:15:09 +0000 UTC"},
+	{in: "2009-08-12 22:15:09.123+0100", out: "2009-08-12 21:15:09.123 +0000 UTC"},
+	{in: "2016-06-21 19:55+0100", out: "2016-06-21 18:55:00 +0000 UTC"},
 	// yyyy.mm
 	{in: "2014.05", out: "2014-05-01 00:00:00 +0000 UTC"},
 	{in: "2018.09.30", out: "2018-09-30 00:00:00 +0000 UTC"},
+	{in: "2018.09.30 17:24", out: "2018-09-30 17:24:00 +0000 UTC"},
+	// yyyy.ddd   day of the year
+	{in: "2014.103", out: "2014-04-13 00:00:00 +0000 UTC"},
+	{in: "2014.103", out: "2014-04-13 06:00:00 +0000 UTC", loc: "America/Denver"},
+	{in: "2014.001", out: "2014-01-01 00:00:00 +0000 UTC"},
+	{in: "2014.365", out: "2014-12-31 00:00:00 +0000 UTC"},
+	{in: "2016.366", out: "2016-12-31 00:00:00 +0000 UTC"},
+	{in: "2014.13", out: "2014-01-13 00:00:00 +0000 UTC"},
+	// yyyy-ddd   ISO 8601 ordinal date, optionally with a time
+	{in: "2014-103", out: "2014-04-13 00:00:00 +0000 UTC"},
+	{in: "2016-366", out: "2016-12-31 00:00:00 +0000 UTC"},
+	{in: "2014-103T09:00:00Z", out: "2014-04-13 09:00:00 +0000 UTC"},
+	{in: "2014-103T09:00:00Z", out: "2014-04-13 09:00:00 +0000 UTC", loc: "America/Denver"},
+	{in: "2014-103T09:00:00+01:00", out: "2014-04-13 08:00:00 +0000 UTC"},
+	{in: "2014-103T09:00:00.123-0700", out: "2014-04-13 16:00:00.123 +0000 UTC"},
+	{in: "2014-103T09:00:00", out: "2014-04-13 15:00:00 +0000 UTC", loc: "America/Denver"},
+	{in: "2014-103 09:00", out: "2014-04-13 09:00:00 +0000 UTC"},
 
 	//   mm.dd.yyyy
 	{in: "3.31.2014", out: "2014-03-31 00:00:00 +0000 UTC"},
 	{in: "3.3.2014", out: "2014-03-03 00:00:00 +0000 UTC"},
 	{in: "03.31.2014", out: "2014-03-31 00:00:00 +0000 UTC"},
+	{in: "03.31.2014 17:24", out: "2014-03-31 17:24:00 +0000 UTC"},
+	{in: "03.31.2014, 17:24:37", out: "2014-03-31 17:24:37 +0000 UTC"},
+	{in: "03.31.2014, 17:24:37", out: "2014-03-31 23:24:37 +0000 UTC", loc: "America/Denver"},
 	//   mm.dd.yy
 	{in: "08.21.71", out: "1971-08-21 00:00:00 +0000 UTC"},
+	//  yyyy-Www-D   ISO 8601 week date
+	{in: "2014-W15-2", out: "2014-04-08 00:00:00 +0000 UTC"},
+	{in: "2014-W15", out: "2014-04-07 00:00:00 +0000 UTC"},
+	{in: "2009-W01-1", out: "2008-12-29 00:00:00 +0000 UTC"},
+	// week 1 holds the first thursday, so may start in the previous year
+	{in: "2020-W01-1", out: "2019-12-30 00:00:00 +0000 UTC"},
+	{in: "2026-W01", out: "2025-12-29 00:00:00 +0000 UTC"},
+	{in: "2010-W01", out: "2010-01-04 00:00:00 +0000 UTC"},
+	// and the last week may end in the next year
+	{in: "2004-W53-6", out: "2005-01-01 00:00:00 +0000 UTC"},
+	{in: "2021-W52-7", out: "2022-01-02 00:00:00 +0000 UTC"},
+	{in: "2015-W53", out: "2015-12-28 00:00:00 +0000 UTC"},
+	{in: "2014-W15-2T09:00:00Z", out: "2014-04-08 09:00:00 +0000 UTC"},
+	{in: "2014-W15-2T09:00:00Z", out: "2014-04-08 09:00:00 +0000 UTC", loc: "America/Denver"},
+	{in: "2014-W15-2T09:00:00+01:00", out: "2014-04-08 08:00:00 +0000 UTC"},
+	{in: "2014-W15-2T09:00:00-0700", out: "2014-04-08 16:00:00 +0000 UTC"},
+	{in: "2014-W15-2T09:00:00.123-07:00", out: "2014-04-08 16:00:00.123 +0000 UTC"},
+	{in: "2014-W15-2 09:00", out: "2014-04-08 09:00:00 +0000 UTC"},
+	{in: "2014-W15-2T09:00:00", out: "2014-04-08 15:00:00 +0000 UTC", loc: "America/Denver"},
+	//  yyyyWwwD   compact ISO 8601 week date
+	{in: "2014W15", out: "2014-04-07 00:00:00 +0000 UTC"},
+	{in: "2014W152", out: "2014-04-08 00:00:00 +0000 UTC"},
+	{in: "2015W537", out: "2016-01-03 00:00:00 +0000 UTC"},
+	{in: "2014W152T09:00:00Z", out: "2014-04-08 09:00:00 +0000 UTC"},
+	{in: "2014W152T09:00:00", out: "2014-04-08 15:00:00 +0000 UTC", loc: "America/Denver"},
+	{in: "2014W15-2", out: "2014-04-08 00:00:00 +0000 UTC"},
+	{in: "2014W15-2T09:00:00Z", out: "2014-04-08 09:00:00 +0000 UTC"},
 	//  yyyymmdd and similar
 	{in: "2014", out: "2014-01-01 00:00:00 +0000 UTC"},
 	{in: "20140601", out: "2014-06-01 00:00:00 +0000 UTC"},
 	{in: "20140722105203", out: "2014-07-22 10:52:03 +0000 UTC"},
+	//  yyyymmddhhmmssZ  GeneralizedTime
+	{in: "20140426172437Z", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "20140426172437Z", out: "2014-04-26 17:24:37 +0000 UTC", loc: "America/Denver"},
+	{in: "20140426172437.123Z", out: "2014-04-26 17:24:37.123 +0000 UTC"},
+	{in: "20140426172437-0500", out: "2014-04-26 22:24:37 +0000 UTC"},
+	{in: "20140426172437.5+0100", out: "2014-04-26 16:24:37.5 +0000 UTC"},
+	{in: "20140426172437.123", out: "2014-04-26 17:24:37.123 +0000 UTC"},
+	//  yymmddhhmmssZ  UTCTime, 50 to 99 are 1950 to 1999
+	{in: "140426172437Z", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "140426172437-0500", out: "2014-04-26 22:24:37 +0000 UTC"},
+	{in: "490426172437Z", out: "2049-04-26 17:24:37 +0000 UTC"},
+	{in: "500426172437Z", out: "1950-04-26 17:24:37 +0000 UTC"},
+	{in: "991231235959Z", out: "1999-12-31 23:59:59 +0000 UTC"},
 
 	// all digits:  unix secs, ms etc
 	{in: "1332151919", out: "2012-03-19 10:11:59 +0000 UTC"},
@@ -378,6 +609,12 @@ var testInputs = []dateTest{
 	{in: "1384216367111", out: "2013-11-12 00:32:47.111 +0000 UTC"},
 	{in: "1384216367111222", out: "2013-11-12 00:32:47.111222 +0000 UTC"},
 	{in: "1384216367111222333", out: "2013-11-12 00:32:47.111222333 +0000 UTC"},
+	// unix secs with a fraction, python logging %(created)f
+	{in: "1384216367.111", out: "2013-11-12 00:32:47.111 +0000 UTC"},
+	{in: "1384216367.111222", out: "2013-11-12 00:32:47.111222 +0000 UTC"},
+	{in: "1384216367.111222", out: "2013-11-12 00:32:47.111222 +0000 UTC", loc: "America/Denver"},
+	{in: "1384216367.111222333", out: "2013-11-12 00:32:47.111222333 +0000 UTC"},
+	{in: "1384216367.5", out: "2013-11-12 00:32:47.5 +0000 UTC"},
 }
 
 func TestParse(t *testing.T) {
@@ -449,7 +686,8 @@ func TestPStruct(t *testing.T) {
 	denverLoc, err := time.LoadLocation("America/Denver")
 	assert.Equal(t, nil, err)
 
-	p := newParser("08.21.71", denverLoc)
+	p, err := newParser("08.21.71", denverLoc)
+	assert.Equal(t, nil, err)
 
 	p.setMonth()
 	assert.Equal(t, 0, p.moi)
@@ -463,96 +701,3044 @@ func TestPStruct(t *testing.T) {
 	assert.True(t, len(p.ts()) > 0)
 }
 
-var testParseErrors = []dateTest{
-	{in: "3", err: true},
-	{in: `{"hello"}`, err: true},
-	{in: "2009-15-12T22:15Z", err: true},
-	{in: "5,000-9,999", err: true},
-	{in: "xyzq-baad"},
-	{in: "oct.-7-1970", err: true},
-	{in: "septe. 7, 1970", err: true},
-	{in: "SeptemberRR 7th, 1970", err: true},
-	{in: "29-06-2016", err: true},
-	// this is just testing the empty space up front
-	{in: " 2018-01-02 17:08:09 -07:00", err: true},
+var testParseErrors = []dateTest{
+	{in: "3", err: true},
+	{in: `{"hello"}`, err: true},
+	{in: "2009-15-12T22:15Z", err: true},
+	{in: "5,000-9,999", err: true},
+	{in: "xyzq-baad"},
+	{in: "oct.-7-1970", err: true},
+	{in: "septe. 7, 1970", err: true},
+	{in: "SeptemberRR 7th, 1970", err: true},
+	{in: "29-06-2016", err: true},
+	{in: "26Foo2014:17:24:37", err: true},
+	{in: "26April2014:17:24:37", err: true},
+	{in: "2014-04-26T24", err: true},
+	{in: "13/2014", err: true},
+	{in: "04/201", err: true},
+	{in: "20140426172437.Z", err: true},
+	{in: "20140426172437Zx", err: true},
+	{in: "20140426172437+05", err: true},
+	{in: "20141326172437Z", err: true},
+	{in: "140426252437Z", err: true},
+	{in: "2014-04-26T25Z", err: true},
+	{in: "2014-04-26T99+01:00", err: true},
+	{in: "26Apr2014 17:24:37", err: true},
+	{in: "25:24:37, Apr 26 2014", err: true},
+	{in: "17:60, Apr 26 2014", err: true},
+	{in: "17:24:37, Apr 31 2014", err: true},
+	{in: "17:24:37, 26 Apr 2014", err: true},
+	{in: "17:24, 2014-04-26", err: true},
+	{in: "2014年04月08日 25时17分22秒", err: true},
+	{in: "2014年04月08日 19时60分", err: true},
+	{in: "99999999-01-01", err: true},
+	{in: "2014-W54-2T09:00:00Z", err: true},
+	{in: "2014-W53", err: true},
+	{in: "2014-W00", err: true},
+	{in: "2014-W15-8T09:00:00Z", err: true},
+	{in: "2014-W15T09:00:00Z", err: true},
+	{in: "2014-W15-2X09:00:00Z", err: true},
+	{in: "2014.366", err: true},
+	{in: "2014.000", err: true},
+	{in: "2017-07-11 04:08:03 (UTC+01:00)", err: true},
+	{in: "26 Foo, 2014", err: true},
+	{in: "26, Foo 2014", err: true},
+	{in: "31 April, 2014", err: true},
+	{in: "Sat Jul 5 16:28:13 2017 PST", err: true},
+	{in: "Sat Jul 5 16:28:13 2017 GMT extra", err: true},
+	{in: "2014-366T09:00:00Z", err: true},
+	{in: "2014-000T09:00:00Z", err: true},
+	{in: "2014-103T25:00:00Z", err: true},
+	{in: "2014W537", err: true},
+	{in: "2014W158", err: true},
+	{in: "2014W1523", err: true},
+	{in: "2014W15T09:00:00Z", err: true},
+	{in: "2014W15-8", err: true},
+	{in: "2014W15-", err: true},
+	{in: "2014W15--2", err: true},
+	{in: "201404", err: true},
+	// offset seconds or minutes over 59
+	{in: "1884-01-01T00:00:00+00:53:60", err: true},
+	{in: "1884-01-01T00:00:00+005360", err: true},
+	{in: "1884-01-01 00:00:00 +00:53:99 LMT", err: true},
+	{in: "2014-04-26T17:24:37+00:60", err: true},
+	// this is just testing the empty space up front
+	{in: " 2018-01-02 17:08:09 -07:00", err: true},
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, th := range testParseErrors {
+		v, err := ParseAny(th.in)
+		assert.NotEqual(t, nil, err, "%v for %v", v, th.in)
+	}
+}
+
+var testParseFormat = []dateTest{
+	// errors
+	{in: "3", err: true},
+	{in: `{"hello"}`, err: true},
+	{in: "2009-15-12T22:15Z", err: true},
+	{in: "5,000-9,999", err: true},
+	//
+	{in: "oct 7, 1970", out: "Jan 2, 2006"},
+	{in: "sept. 7, 1970", out: "Jan. 2, 2006"},
+	{in: "May 05, 2015, 05:05:07", out: "Jan 02, 2006, 15:04:05"},
+	// 03 February 2013
+	{in: "03 February 2013", out: "02 January 2006"},
+	// 13:31:51.999 -07:00 MST
+	//   yyyy-mm-dd hh:mm:ss +00:00
+	{in: "2012-08-03 18:31:59 +00:00", out: "2006-01-02 15:04:05 -07:00"},
+	//   yyyy-mm-dd hh:mm:ss +0000 TZ
+	// Golang Native Format
+	{in: "2012-08-03 18:31:59 +0000 UTC", out: "2006-01-02 15:04:05 -0700 UTC"},
+	//   yyyy-mm-dd hh:mm:ss TZ
+	{in: "2012-08-03 18:31:59 UTC", out: "2006-01-02 15:04:05 UTC"},
+	//   yyyy-mm-dd hh:mm:ss +00
+	{in: "2014-04-26 17:24:37 +08", out: "2006-01-02 15:04:05 -07"},
+	{in: "2014-04-26 17:24:37.123 -05", out: "2006-01-02 15:04:05.000 -07"},
+	{in: "2014-04-26 10:24:37 -07 PDT", out: "2006-01-02 15:04:05 -07 PDT"},
+	//   yyyy-mm-ddThh:mm:ss-07:00
+	{in: "2009-08-12T22:15:09-07:00", out: "2006-01-02T15:04:05-07:00"},
+	//   yyyy-mm-ddThh:mm:ss-0700
+	{in: "2009-08-12T22:15:09-0700", out: "2006-01-02T15:04:05-0700"},
+	// Mon, 02 Jan 06 15:04:05 -0700
+	{in: "Mon, 02 Jan 06 15:04:05 -0700", out: "Mon, 02 Jan 06 15:04:05 -0700"},
+	{in: "Mon, 2 Jan 06 15:04 +0100", out: "Mon, 2 Jan 06 15:04 -0700"},
+	{in: "Monday, 02-Jan-06 15:04:05 MST", out: "Monday, 02-Jan-06 15:04:05 MST"},
+	{in: "Saturday, 26 April 2014", out: "Monday, 02 January 2006"},
+	//   yyyy-mm-ddThh:mm:ssZ
+	{in: "2009-08-12T22:15Z", out: "2006-01-02T15:04Z"},
+	{in: "2009-08-12 22:15:09Z", out: "2006-01-02 15:04:05Z"},
+	{in: "2009-08-12 22:15:09.123-07:00", out: "2006-01-02 15:04:05.000-07:00"},
+}
+
+func TestParseError(t *testing.T) {
+	for _, th := range []struct {
+		in       string
+		reason   string
+		position int
+		length   int
+	}{
+		{in: "2014-13-13 08:20:13,787", reason: "month out of range", position: 5, length: 2},
+		{in: "2014-04-31", reason: "day out of range", position: 8, length: 2},
+		{in: "04/31/2014", reason: "day out of range", position: 3, length: 2},
+		{in: "Apr 32, 2014", reason: "day out of range", position: 4, length: 2},
+		{in: "2014-04-26 25:00:00", reason: "hour out of range", position: 11, length: 2},
+		{in: "foo", reason: "unknown format", position: -1},
+	} {
+		_, err := ParseAny(th.in)
+		pe, ok := err.(*ParseError)
+		assert.True(t, ok, "for in=%v", th.in)
+		if !ok {
+			continue
+		}
+		assert.Equal(t, th.in, pe.Input, "for in=%v", th.in)
+		assert.Equal(t, th.reason, pe.Reason, "for in=%v", th.in)
+		assert.Equal(t, th.position, pe.Position, "for in=%v", th.in)
+		assert.Equal(t, th.length, pe.Length, "for in=%v", th.in)
+	}
+
+	// the message is unchanged
+	_, err := ParseAny("2014-04-31")
+	assert.Equal(t, `parsing time "2014-04-31": day out of range`, fmt.Sprintf("%v", err))
+
+	// ParseIn and the pedantic ranges too
+	_, err = ParseIn("2014-13-13", time.UTC)
+	pe, ok := err.(*ParseError)
+	assert.True(t, ok)
+	if ok {
+		assert.Equal(t, 5, pe.Position)
+	}
+	_, err = ParseAny("2014-04-26 17:60:00", WithPedanticRanges(true))
+	pe, ok = err.(*ParseError)
+	assert.True(t, ok)
+	if ok {
+		assert.Equal(t, "minute out of range", pe.Reason)
+		assert.Equal(t, 14, pe.Position)
+		assert.Equal(t, 2, pe.Length)
+	}
+
+	// the time.ParseError is kept
+	_, err = ParseAny("2014-04-31")
+	var tpe *time.ParseError
+	assert.True(t, errors.As(err, &tpe))
+	if tpe != nil {
+		assert.Equal(t, ": day out of range", tpe.Message)
+	}
+	assert.Equal(t, nil, errors.Unwrap(&ParseError{}))
+	_, err = ParseAny("foo")
+	assert.False(t, errors.As(err, &tpe))
+
+	// the errors of options are as they are
+	_, err = ParseAny("2014-04-26", WithBusinessDaysOnly(nil))
+	assert.Equal(t, ErrNotBusinessDay, err)
+}
+
+func TestParseLayout(t *testing.T) {
+	for _, th := range testParseFormat {
+		l, err := ParseFormat(th.in)
+		if th.err {
+			assert.NotEqual(t, nil, err)
+		} else {
+			assert.Equal(t, nil, err)
+			assert.Equal(t, th.out, l, "for in=%v", th.in)
+		}
+	}
+}
+
+var testParseStrict = []dateTest{
+	//   dd-mon-yy  13-Feb-03
+	{in: "03-03-14"},
+	//   mm.dd.yyyy
+	{in: "3.3.2014"},
+	//   mm.dd.yy
+	{in: "08.09.71"},
+	//  mm/dd/yyyy
+	{in: "3/5/2014"},
+	//  mm/dd/yy
+	{in: "08/08/71"},
+	{in: "8/8/71"},
+	//  mm/dd/yy hh:mm:ss
+	{in: "04/02/2014 04:08:09"},
+	{in: "4/2/2014 04:08:09"},
+}
+
+func TestParseStrict(t *testing.T) {
+
+	for _, th := range testParseStrict {
+		_, err := ParseStrict(th.in)
+		assert.NotEqual(t, nil, err)
+	}
+
+	_, err := ParseStrict(`{"hello"}`)
+	assert.NotEqual(t, nil, err)
+
+	_, err = ParseStrict("2009-08-12T22:15Z")
+	assert.Equal(t, nil, err)
+}
+
+var testParseOrder = []struct {
+	in    string
+	order FieldOrder
+	out   string
+}{
+	// ambiguous numeric dates follow the order
+	{in: "02/01/2014", order: OrderMDY, out: "2014-02-01 00:00:00 +0000 UTC"},
+	{in: "02/01/2014", order: OrderDMY, out: "2014-01-02 00:00:00 +0000 UTC"},
+	{in: "02/01/14", order: OrderYMD, out: "2002-01-14 00:00:00 +0000 UTC"},
+	{in: "02/01/2014 10:11:12", order: OrderDMY, out: "2014-01-02 10:11:12 +0000 UTC"},
+	{in: "2/1/06 4:05 PM", order: OrderDMY, out: "2006-01-02 16:05:00 +0000 UTC"},
+	{in: "02.01.2014", order: OrderMDY, out: "2014-02-01 00:00:00 +0000 UTC"},
+	{in: "02.01.2014", order: OrderDMY, out: "2014-01-02 00:00:00 +0000 UTC"},
+	{in: "14.02.03", order: OrderYMD, out: "2014-02-03 00:00:00 +0000 UTC"},
+	// unambiguous layouts ignore the order
+	{in: "2014/02/01", order: OrderDMY, out: "2014-02-01 00:00:00 +0000 UTC"},
+	{in: "2014.02.01", order: OrderDMY, out: "2014-02-01 00:00:00 +0000 UTC"},
+	{in: "1 February 2014", order: OrderMDY, out: "2014-02-01 00:00:00 +0000 UTC"},
+	{in: "Feb 1, 2014", order: OrderDMY, out: "2014-02-01 00:00:00 +0000 UTC"},
+}
+
+func TestParseOrder(t *testing.T) {
+	for _, th := range testParseOrder {
+		ts, err := ParseOrder(th.in, th.order)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v order=%v", th.in, th.order)
+	}
+
+	// default ParseAny is month first
+	ts, err := ParseAny("02/01/2014")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-02-01 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// month out of range under the given order is an error
+	_, err = ParseOrder("13/02/2014", OrderMDY)
+	assert.NotEqual(t, nil, err)
+	_, err = ParseOrder("02/13/2014", OrderDMY)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestPreferDayFirst(t *testing.T) {
+	for _, th := range []struct {
+		in       string
+		dayFirst bool
+		out      string
+	}{
+		{in: "01/02/2014", dayFirst: true, out: "2014-02-01 00:00:00 +0000 UTC"},
+		{in: "01/02/2014", dayFirst: false, out: "2014-01-02 00:00:00 +0000 UTC"},
+		{in: "13/02/2014", dayFirst: true, out: "2014-02-13 00:00:00 +0000 UTC"},
+		// only valid month first, whatever the preference
+		{in: "02/13/2014", dayFirst: true, out: "2014-02-13 00:00:00 +0000 UTC"},
+		{in: "02/13/2014", dayFirst: false, out: "2014-02-13 00:00:00 +0000 UTC"},
+		{in: "1.13.2014 17:24", dayFirst: true, out: "2014-01-13 17:24:00 +0000 UTC"},
+		{in: "1-13-2014", dayFirst: true, out: "2014-01-13 00:00:00 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, PreferDayFirst(th.dayFirst))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v dayFirst=%v", th.in, th.dayFirst)
+	}
+
+	layout, err := ParseFormat("02/13/2014", PreferDayFirst(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "01/02/2006", layout)
+
+	// valid in neither order
+	for _, in := range []string{"13/13/2014", "02/30/2014"} {
+		_, err := ParseAny(in, PreferDayFirst(true))
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+		_, err = ParseAny(in, PreferDayFirst(false))
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+
+	// only valid day first, whatever the preference
+	for _, in := range []string{"13/02/2014", "13.02.2014", "13/02/2014 17:24"} {
+		for _, opts := range [][]ParserOption{nil, {PreferDayFirst(false)}, {PreferDayFirst(true)}} {
+			ts, err := ParseAny(in, opts...)
+			assert.Equal(t, nil, err, "for in=%v", in)
+			assert.Equal(t, "2014-02-13", ts.Format("2006-01-02"), "for in=%v", in)
+			layout, err := ParseFormat(in, opts...)
+			assert.Equal(t, nil, err, "for in=%v", in)
+			assert.Equal(t, "02", layout[:2], "for in=%v", in)
+		}
+	}
+
+	// month first, explicitly the same as by default
+	for _, in := range []string{"13/02/2014", "13.02.2014", "13-02-2014", "01/02/2014", "02/13/2014", "29-06-2016"} {
+		want, wantErr := ParseAny(in)
+		ts, err := ParseAny(in, PreferDayFirst(false))
+		assert.Equal(t, wantErr, err, "for in=%v", in)
+		assert.Equal(t, want, ts, "for in=%v", in)
+	}
+	// the dashes of 29-06-2016 only when day first is preferred
+	_, err = ParseAny("29-06-2016", PreferDayFirst(false))
+	assert.NotEqual(t, nil, err)
+	ts, err := ParseAny("29-06-2016", PreferDayFirst(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2016-06-29 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// a field order of ParseOrder is not second guessed
+	_, err = ParseOrder("13/02/2014", OrderMDY)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestImpliedMillis(t *testing.T) {
+	// off by default
+	_, err := ParseAny("2014-04-26 17:24:37123")
+	assert.NotEqual(t, nil, err)
+
+	ts, err := ParseAny("2014-04-26 17:24:37123", WithImpliedMillis(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 17:24:37.123 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	ts, err = ParseAny("2014-04-26T17:24:37123Z", WithImpliedMillis(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 17:24:37.123 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	ts, err = ParseAny("2014-04-26 17:24:37123 +0100", WithImpliedMillis(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 16:24:37.123 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// normal inputs are unchanged by the option
+	ts, err = ParseAny("2014-04-26 17:24:37.3186369", WithImpliedMillis(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 17:24:37.3186369 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// only exactly 3 digits directly after the seconds
+	_, err = ParseAny("2014-04-26 17:24:371234", WithImpliedMillis(true))
+	assert.NotEqual(t, nil, err)
+	_, err = ParseAny("2014-04-26 17:24:3712", WithImpliedMillis(true))
+	assert.NotEqual(t, nil, err)
+	_, err = ParseAny("2014-04-26 17:24123", WithImpliedMillis(true))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestFractionalEpoch(t *testing.T) {
+	for _, th := range []struct {
+		in    string
+		nanos int
+	}{
+		{"1384216367.111", 111000000},
+		{"1384216367.111222", 111222000},
+		{"1384216367.111222333", 111222333},
+		{"1384216367.000001", 1000},
+	} {
+		ts, err := ParseAny(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, int64(1384216367), ts.Unix(), "for in=%v", th.in)
+		assert.Equal(t, th.nanos, ts.Nanosecond(), "for in=%v", th.in)
+	}
+
+	// no more than nanoseconds
+	_, err := ParseAny("1384216367.1112223334")
+	assert.NotEqual(t, nil, err)
+
+	// the period is not a date separator
+	ts, err := ParseAny("1384216367.111", WithAllowedSeparators('-'))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1384216367111), ts.UnixNano()/int64(time.Millisecond))
+	_, err = ParseAny("04.26.2014", WithAllowedSeparators('-'))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestParseToUnix(t *testing.T) {
+	secs, err := ParseToUnix("2013-11-12 00:32:47.111222333")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1384216367), secs)
+
+	ms, err := ParseToUnixMilli("2013-11-12 00:32:47.111222333")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1384216367111), ms)
+
+	ns, err := ParseToUnixNano("2013-11-12 00:32:47.111222333")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1384216367111222333), ns)
+
+	ms, err = ParseToUnixMilli("2013-11-12T01:32:47.5+01:00")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1384216367500), ms)
+
+	ms, err = ParseToUnixMilli("1384216367111")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1384216367111), ms)
+
+	ms, err = ParseToUnixMilli("2014-04-26 17:24:37123", WithImpliedMillis(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1398533077123), ms)
+
+	// beyond the range of UnixNano
+	ms, err = ParseToUnixMilli("1500-01-01")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(-14831769600000), ms)
+	ms, err = ParseToUnixMilli("3000-01-01")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(32503680000000), ms)
+	ms, err = ParseToUnixMilli("1969-12-31 23:59:59.5")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(-500), ms)
+	_, err = ParseToUnixNano("1300-01-01")
+	assert.NotEqual(t, nil, err)
+	_, err = ParseToUnixNano("2300-01-01")
+	assert.NotEqual(t, nil, err)
+	ns, err = ParseToUnixNano("1700-01-01")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(-8520336000000000000), ns)
+
+	_, err = ParseToUnix("INVALID")
+	assert.NotEqual(t, nil, err)
+	_, err = ParseToUnixMilli("INVALID")
+	assert.NotEqual(t, nil, err)
+	_, err = ParseToUnixNano("INVALID")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBusinessDaysOnly(t *testing.T) {
+	// no option, weekends are fine
+	_, err := ParseAny("2014-04-26 17:24:37")
+	assert.Equal(t, nil, err)
+
+	// Saturday and Sunday
+	_, err = ParseAny("2014-04-26 17:24:37", WithBusinessDaysOnly(nil))
+	assert.Equal(t, ErrNotBusinessDay, err)
+	_, err = ParseAny("Sun, 27 Apr 2014 10:00:00 +0000", WithBusinessDaysOnly(nil))
+	assert.Equal(t, ErrNotBusinessDay, err)
+
+	// Friday
+	ts, err := ParseAny("2014-04-25 17:24:37", WithBusinessDaysOnly(nil))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-25 17:24:37 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// holidays match on date only
+	holidays := []time.Time{
+		time.Date(2014, time.December, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2014, time.July, 4, 12, 30, 0, 0, time.UTC),
+	}
+	_, err = ParseAny("12/25/2014 09:00", WithBusinessDaysOnly(holidays))
+	assert.Equal(t, ErrNotBusinessDay, err)
+	_, err = ParseAny("2014-07-04T23:59:59Z", WithBusinessDaysOnly(holidays))
+	assert.Equal(t, ErrNotBusinessDay, err)
+	_, err = ParseAny("2014-07-03T23:59:59Z", WithBusinessDaysOnly(holidays))
+	assert.Equal(t, nil, err)
+}
+
+func TestParseFirstLast(t *testing.T) {
+	inputs := []string{
+		"INVALID",
+		"2014-04-26 17:24:37",
+		"Fri, 25 Apr 2014 10:00:00 +0100",
+		"1398533077",
+		`{"ts":"now"}`,
+		"May 8, 2014 5:57:51 PM",
+	}
+	ts, err := ParseFirst(inputs...)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-25 09:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	ts, err = ParseLast(inputs...)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-05-08 17:57:51 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// single valid input is both first and last
+	ts, err = ParseFirst("INVALID", "2014-04-26")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+	ts, err = ParseLast("2014-04-26", "INVALID")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// error only when nothing parses
+	_, err = ParseFirst("INVALID", "xyzq-baad")
+	assert.NotEqual(t, nil, err)
+	_, err = ParseLast()
+	assert.NotEqual(t, nil, err)
+}
+
+var testFrenchTime = []dateTest{
+	{in: "2014-04-26 17 h 24", out: "2014-04-26 17:24:00 +0000 UTC"},
+	{in: "2014-04-26 17 h", out: "2014-04-26 17:00:00 +0000 UTC"},
+	{in: "2014-04-26 9 h 05", out: "2014-04-26 09:05:00 +0000 UTC"},
+	{in: "2014-04-26 17h24", out: "2014-04-26 17:24:00 +0000 UTC"},
+	{in: "2014-04-26 17h", out: "2014-04-26 17:00:00 +0000 UTC"},
+	{in: "2014-04-26 17H24", out: "2014-04-26 17:24:00 +0000 UTC"},
+	{in: "2014-04-26T17h24", out: "2014-04-26 17:24:00 +0000 UTC"},
+	// month names
+	{in: "26 avril 2014", out: "2014-04-26 00:00:00 +0000 UTC"},
+	{in: "avril 2014", out: "2014-04-01 00:00:00 +0000 UTC"},
+	{in: "2014 avril", out: "2014-04-01 00:00:00 +0000 UTC"},
+	{in: "Février 2014", out: "2014-02-01 00:00:00 +0000 UTC"},
+	{in: "2014 août", out: "2014-08-01 00:00:00 +0000 UTC"},
+	{in: "déc. 2014", out: "2014-12-01 00:00:00 +0000 UTC"},
+	// numeric dates are day first
+	{in: "02/01/2014", out: "2014-01-02 00:00:00 +0000 UTC"},
+	{in: "26/04/2014 17h24", out: "2014-04-26 17:24:00 +0000 UTC"},
+	{in: "02.01.2014", out: "2014-01-02 00:00:00 +0000 UTC"},
+	// unchanged
+	{in: "2014-04-26 17:24", out: "2014-04-26 17:24:00 +0000 UTC"},
+	{in: "2014-04-26", out: "2014-04-26 00:00:00 +0000 UTC"},
+}
+
+func TestFrenchTime(t *testing.T) {
+	for _, th := range testFrenchTime {
+		ts, err := ParseAny(th.in, WithLanguage("fr"))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	// only under the french language
+	_, err := ParseAny("2014-04-26 17 h 24")
+	assert.NotEqual(t, nil, err)
+
+	// english numeric dates are month first
+	ts, err := ParseAny("02/01/2014")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-02-01 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts))
+
+	// an explicit order is kept, whatever the order of the options
+	for _, opts := range [][]ParserOption{
+		{WithLanguage("fr"), PreferDayFirst(false)},
+		{PreferDayFirst(false), WithLanguage("fr")},
+	} {
+		ts, err = ParseAny("02/01/2014", opts...)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "2014-02-01 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts))
+	}
+	ts, err = ParseOrder("02/01/2014", OrderMDY, WithLanguage("fr"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-02-01 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts))
+
+	_, err = ParseAny("2014-04-26", WithLanguage("tlh"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestMonthNamesOverride(t *testing.T) {
+	names := map[string]time.Month{
+		"enero": time.January, "ene": time.January,
+		"abril": time.April, "abr": time.April,
+		"févr": time.February, "fév": time.February,
+		"diciembre": time.December, "dic": time.December,
+	}
+	for _, th := range []dateTest{
+		{in: "26 abril 2014", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "Abril 26, 2014 17:24:37", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "ene 2, 2006", out: "2006-01-02 00:00:00 +0000 UTC"},
+		{in: "31-dic-2014", out: "2014-12-31 00:00:00 +0000 UTC"},
+		{in: "28-fév-2014", out: "2014-02-28 00:00:00 +0000 UTC"},
+		{in: "févr 28, 2014", out: "2014-02-28 00:00:00 +0000 UTC"},
+		// numeric dates are unchanged
+		{in: "2014-04-26", out: "2014-04-26 00:00:00 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, WithMonthNamesOverride(names))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	// the english names are replaced, not extended
+	for _, in := range []string{"Jan 2, 2006", "26 April 2014", "31-Dec-2014"} {
+		_, err := ParseAny(in, WithMonthNamesOverride(names))
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+
+		_, err = ParseAny(in)
+		assert.Equal(t, nil, err, "for in=%v", in)
+	}
+
+	// nil restores the english names
+	_, err := ParseAny("Jan 2, 2006", WithMonthNamesOverride(names), WithMonthNamesOverride(nil))
+	assert.Equal(t, nil, err)
+}
+
+func TestWeekdayAnnotation(t *testing.T) {
+	for _, th := range []struct {
+		in, lang, out string
+	}{
+		{in: "2014-04-26 (Sat)", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "2014-04-26(Sat)", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "2014-04-26 (Saturday)", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "04/26/2014 17:24 (sat)", out: "2014-04-26 17:24:00 +0000 UTC"},
+		{in: "2014-04-26(土)", lang: "ja", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "2014-04-26 (土曜日)", lang: "ja", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "2014年04月26日(土)", lang: "ja", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "2014-04-26(Sat)", lang: "ja", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "2014/04/26(土) 17:24", lang: "ja", out: "2014-04-26 17:24:00 +0000 UTC"},
+		{in: "2014/04/26 (土) 17:24:37", lang: "ja", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "2014/04/26(土曜日) 17:24", lang: "ja", out: "2014-04-26 17:24:00 +0000 UTC"},
+		{in: "2014-04-26(六)", lang: "zh", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "2014-04-27(星期日)", lang: "zh", out: "2014-04-27 00:00:00 +0000 UTC"},
+		{in: "2014-04-27 (周日)", lang: "zh", out: "2014-04-27 00:00:00 +0000 UTC"},
+	} {
+		opts := []ParserOption{ValidateWeekday(true)}
+		if th.lang != "" {
+			opts = append(opts, WithLanguage(th.lang))
+		}
+		ts, err := ParseAny(th.in, opts...)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	// the annotation is skipped by default, validated only when asked
+	ts, err := ParseAny("2014-04-26 (Mon)")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts))
+	ts, err = ParseAny("2014/04/26(金) 17:24", WithLanguage("ja"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 17:24:00 +0000 UTC", fmt.Sprintf("%v", ts))
+	for _, th := range []struct {
+		in, lang string
+	}{
+		{in: "2014-04-26 (Mon)"},
+		{in: "2014-04-26(月)", lang: "ja"},
+		{in: "2014/04/26(金) 17:24", lang: "ja"},
+		{in: "2014-04-26(日)", lang: "zh"},
+	} {
+		opts := []ParserOption{ValidateWeekday(true)}
+		if th.lang != "" {
+			opts = append(opts, WithLanguage(th.lang))
+		}
+		_, err := ParseAny(th.in, opts...)
+		assert.NotEqual(t, nil, err, "for in=%v", th.in)
+	}
+
+	// the CJK names only under their language
+	_, err = ParseAny("2014-04-26(土)")
+	assert.NotEqual(t, nil, err)
+	_, err = ParseAny("2014-04-26(土)", WithLanguage("zh"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestPreserveZoneName(t *testing.T) {
+	// default, the offset is kept but not the name
+	ts, err := ParseAny("2015-02-08 03:02:00 +0300 MSK")
+	assert.Equal(t, nil, err)
+	zone, offset := ts.Zone()
+	assert.Equal(t, "", zone)
+	assert.Equal(t, 10800, offset)
+
+	for _, in := range []string{
+		"2015-02-08 03:02:00 +0300 MSK",
+		"2015-02-08 03:02:00.001 +0300 MSK",
+		"2015-02-08 03:02:00 +03:00 MSK",
+		"2015-02-08 03:02:00.001 +0300 MSK m=+0.000000001",
+	} {
+		ts, err = ParseAny(in, WithPreserveZoneName(true))
+		assert.Equal(t, nil, err, in)
+		zone, offset = ts.Zone()
+		assert.Equal(t, "MSK", zone, in)
+		assert.Equal(t, 10800, offset, in)
+		assert.Equal(t, "2015-02-08 00:02:00", ts.In(time.UTC).Format("2006-01-02 15:04:05"), in)
+	}
+
+	// names already parsed as a zone are kept as well
+	ts, err = ParseAny("Mon Aug 10 15:44:11 CEST+0200 2015", WithPreserveZoneName(true))
+	assert.Equal(t, nil, err)
+	zone, offset = ts.Zone()
+	assert.Equal(t, "CEST", zone)
+	assert.Equal(t, 7200, offset)
+
+	// unknown names are trusted
+	ts, err = ParseAny("2015-02-08 03:02:00 +0300 XYZ", WithPreserveZoneName(true))
+	assert.Equal(t, nil, err)
+	zone, offset = ts.Zone()
+	assert.Equal(t, "XYZ", zone)
+	assert.Equal(t, 10800, offset)
+
+	// known names not matching the offset are kept unless checked
+	ts, err = ParseAny("2015-02-08 03:02:00 +0200 MSK", WithPreserveZoneName(true))
+	assert.Equal(t, nil, err)
+	zone, offset = ts.Zone()
+	assert.Equal(t, "MSK", zone)
+	assert.Equal(t, 7200, offset)
+	_, err = ParseAny("2015-02-08 03:02:00 +0200 MSK", WithPreserveZoneName(true), WithZoneConflictCheck(true))
+	assert.NotEqual(t, nil, err)
+	_, err = ParseAny("2015-02-08 03:02:00 +02:00 MSK", WithPreserveZoneName(true), WithZoneConflictCheck(true))
+	assert.NotEqual(t, nil, err)
+}
+
+var testRelativeEpoch = []dateTest{
+	{in: "epoch", out: "1970-01-01 00:00:00 +0000 UTC"},
+	{in: "EPOCH", out: "1970-01-01 00:00:00 +0000 UTC"},
+	{in: "epoch+86400", out: "1970-01-02 00:00:00 +0000 UTC"},
+	{in: "epoch-86400", out: "1969-12-31 00:00:00 +0000 UTC"},
+	{in: "epoch+1384216367", out: "2013-11-12 00:32:47 +0000 UTC"},
+	{in: "epoch-1h", out: "1969-12-31 23:00:00 +0000 UTC"},
+	{in: "epoch+1h30m", out: "1970-01-01 01:30:00 +0000 UTC"},
+	{in: "epoch+1.5s", out: "1970-01-01 00:00:01.5 +0000 UTC"},
+	{in: "epoch+86400", out: "1970-01-02 00:00:00 +0000 UTC", loc: "America/Denver"},
+	// errors
+	{in: "epoch+", err: true},
+	{in: "epoch86400", err: true},
+	{in: "epoch+1 day", err: true},
+	{in: "epoch+1x", err: true},
+}
+
+func TestRelativeEpoch(t *testing.T) {
+	for _, th := range testRelativeEpoch {
+		var ts time.Time
+		var err error
+		if th.loc != "" {
+			loc, lerr := time.LoadLocation(th.loc)
+			assert.Equal(t, nil, lerr)
+			ts, err = ParseIn(th.in, loc, EnableRelative(true))
+		} else {
+			ts, err = ParseAny(th.in, EnableRelative(true))
+		}
+		if th.err {
+			assert.NotEqual(t, nil, err, "for in=%v", th.in)
+			continue
+		}
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	// only when enabled
+	_, err := ParseAny("epoch+86400")
+	assert.NotEqual(t, nil, err)
+}
+
+var testGerman = []dateTest{
+	{in: "Montag, 26. April 2014", out: "2014-04-26 00:00:00 +0000 UTC"},
+	{in: "Samstag, 26. April 2014", out: "2014-04-26 00:00:00 +0000 UTC"},
+	{in: "Mittwoch, 5. März 2014", out: "2014-03-05 00:00:00 +0000 UTC"},
+	{in: "26. April 2014", out: "2014-04-26 00:00:00 +0000 UTC"},
+	{in: "1. Dezember 2014", out: "2014-12-01 00:00:00 +0000 UTC"},
+	{in: "26. april 2014", out: "2014-04-26 00:00:00 +0000 UTC"},
+	{in: "26 Okt 2014", out: "2014-10-26 00:00:00 +0000 UTC"},
+	{in: "Sa, 26 Apr 2014 17:24:37 +0200", out: "2014-04-26 15:24:37 +0000 UTC"},
+	{in: "Samstag, 26. April 2014 17:24", out: "2014-04-26 17:24:00 +0000 UTC"},
+	// month year
+	{in: "März 2014", out: "2014-03-01 00:00:00 +0000 UTC"},
+	{in: "2014 März", out: "2014-03-01 00:00:00 +0000 UTC"},
+	{in: "Dezember 2014", out: "2014-12-01 00:00:00 +0000 UTC"},
+	{in: "2014 Okt", out: "2014-10-01 00:00:00 +0000 UTC"},
+	// numeric dates are unchanged
+	{in: "2014-04-26", out: "2014-04-26 00:00:00 +0000 UTC"},
+	{in: "2014.04.26", out: "2014-04-26 00:00:00 +0000 UTC"},
+}
+
+func TestGerman(t *testing.T) {
+	for _, th := range testGerman {
+		ts, err := ParseAny(th.in, WithLanguage("de"))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	// only under the german language
+	_, err := ParseAny("Montag, 26. April 2014")
+	assert.NotEqual(t, nil, err)
+
+	// the translated weekday is checked under ValidateWeekday
+	ts, err := ParseAny("Samstag, 26. April 2014", WithLanguage("de"), ValidateWeekday(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+	_, err = ParseAny("Montag, 26. April 2014", WithLanguage("de"), ValidateWeekday(true))
+	assert.NotEqual(t, nil, err)
+	_, err = ParseAny("Montag, 26. April 2014", WithLanguage("de"))
+	assert.Equal(t, nil, err)
+
+	// the layout parses the date-string as given
+	for _, th := range []struct {
+		in, layout string
+	}{
+		{in: "Montag, 26. April 2014", layout: "Montag, 02. January 2006"},
+		{in: "Samstag, 26. April 2014 17:24", layout: "Samstag, 02. January 2006 15:04"},
+		{in: "26. april 2014", layout: "02. January 2006"},
+		{in: "Sa, 26 Apr 2014 17:24:37 +0200", layout: "Sa, 02 Jan 2006 15:04:05 -0700"},
+	} {
+		ts, layout, err := ParseAnyWithFormat(th.in, WithLanguage("de"))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.layout, layout, "for in=%v", th.in)
+		again, err := time.Parse(layout, th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.True(t, ts.Equal(again), "for in=%v", th.in)
+	}
+	// no layout reads a german month name
+	layout, err := ParseFormat("26. März 2014", WithLanguage("de"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "02 January 2006", layout)
+}
+
+var testNormalize = []struct {
+	in, out, keep string
+}{
+	{in: "2012-08-03 18:31:59.257000000", out: "2012-08-03T18:31:59.257Z", keep: "2012-08-03T18:31:59.257000000Z"},
+	{in: "2012-08-03 18:31:59.257000000 +0000 UTC", out: "2012-08-03T18:31:59.257Z", keep: "2012-08-03T18:31:59.257000000Z"},
+	{in: "2009-08-12T22:15:09.120-07:00", out: "2009-08-12T22:15:09.12-07:00", keep: "2009-08-12T22:15:09.120-07:00"},
+	{in: "2014-04-26 17:24:37.3186369", out: "2014-04-26T17:24:37.3186369Z", keep: "2014-04-26T17:24:37.3186369Z"},
+	{in: "2014-04-26 17:24:37.000", out: "2014-04-26T17:24:37Z", keep: "2014-04-26T17:24:37.000Z"},
+	{in: "2014-04-26 17:24:37", out: "2014-04-26T17:24:37Z", keep: "2014-04-26T17:24:37Z"},
+	{in: "1384216367111", out: "2013-11-12T00:32:47.111Z", keep: "2013-11-12T00:32:47.111Z"},
+}
+
+func TestNormalize(t *testing.T) {
+	for _, th := range testNormalize {
+		s, err := Normalize(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, s, "for in=%v", th.in)
+
+		s, err = Normalize(th.in, WithKeepFractionDigits(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.keep, s, "for in=%v", th.in)
+
+		// the instant is the same either way
+		t1, err := ParseAny(th.in)
+		assert.Equal(t, nil, err)
+		t2, err := ParseAny(th.in, WithKeepFractionDigits(true))
+		assert.Equal(t, nil, err)
+		assert.True(t, t1.Equal(t2), "for in=%v", th.in)
+	}
+
+	_, err := Normalize("INVALID")
+	assert.NotEqual(t, nil, err)
+}
+
+// Debezium and other change data capture timestamps, the postgres text
+// form with an hour only offset
+var testChangeDataCapture = []struct {
+	in, out, layout string
+}{
+	{in: "2014-04-26 17:24:37.123456+00", out: "2014-04-26 17:24:37.123456 +0000 UTC", layout: "2006-01-02 15:04:05.000000-07"},
+	{in: "2014-04-26 17:24:37.123456+05", out: "2014-04-26 12:24:37.123456 +0000 UTC", layout: "2006-01-02 15:04:05.000000-07"},
+	{in: "2014-04-26 17:24:37.123456-08", out: "2014-04-27 01:24:37.123456 +0000 UTC", layout: "2006-01-02 15:04:05.000000-07"},
+	{in: "2014-04-26 17:24:37.1+00", out: "2014-04-26 17:24:37.1 +0000 UTC", layout: "2006-01-02 15:04:05.0-07"},
+	{in: "2014-04-26 17:24:37+05", out: "2014-04-26 12:24:37 +0000 UTC", layout: "2006-01-02 15:04:05-07"},
+}
+
+func TestChangeDataCapture(t *testing.T) {
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	for _, th := range testChangeDataCapture {
+		ts, err := ParseAny(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+
+		// the offset wins over the location
+		ts, err = ParseIn(th.in, denverLoc)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+
+		layout, err := ParseFormat(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.layout, layout, "for in=%v", th.in)
+	}
+}
+
+// Apple system log (ASL) and CFDateFormatter default timestamps
+var testAppleLog = []struct {
+	in, out, layout string
+}{
+	{in: "2014-04-26 17:24:37.123 -0700", out: "2014-04-27 00:24:37.123 +0000 UTC", layout: "2006-01-02 15:04:05.000 -0700"},
+	{in: "2014-04-26 17:24:37.123 +0000", out: "2014-04-26 17:24:37.123 +0000 UTC", layout: "2006-01-02 15:04:05.000 -0700"},
+	{in: "2014-04-26 17:24:37.123456 -0700", out: "2014-04-27 00:24:37.123456 +0000 UTC", layout: "2006-01-02 15:04:05.000000 -0700"},
+	{in: "2014-04-26 17:24:37.123456 +0530", out: "2014-04-26 11:54:37.123456 +0000 UTC", layout: "2006-01-02 15:04:05.000000 -0700"},
+	{in: "2014-04-26 17:24:37.123456 +0000", out: "2014-04-26 17:24:37.123456 +0000 UTC", layout: "2006-01-02 15:04:05.000000 -0700"},
+	{in: "2014-04-26 17:24:37.1 -0700", out: "2014-04-27 00:24:37.1 +0000 UTC", layout: "2006-01-02 15:04:05.0 -0700"},
+	{in: "2014-04-26 17:24:37.123456789 -0700", out: "2014-04-27 00:24:37.123456789 +0000 UTC", layout: "2006-01-02 15:04:05.000000000 -0700"},
+	{in: "2014-04-26 17:24:37.123-0700", out: "2014-04-27 00:24:37.123 +0000 UTC", layout: "2006-01-02 15:04:05.000-0700"},
+	{in: "2014-04-26 17:24:37.123456-0700", out: "2014-04-27 00:24:37.123456 +0000 UTC", layout: "2006-01-02 15:04:05.000000-0700"},
+	{in: "2014-04-26 17:24:37 -0700", out: "2014-04-27 00:24:37 +0000 UTC", layout: "2006-01-02 15:04:05 -0700"},
+}
+
+func TestAppleLog(t *testing.T) {
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	for _, th := range testAppleLog {
+		ts, err := ParseAny(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+
+		// the offset wins over the location
+		ts, err = ParseIn(th.in, denverLoc)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+
+		layout, err := ParseFormat(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.layout, layout, "for in=%v", th.in)
+	}
+}
+
+func TestRequireFourDigitYear(t *testing.T) {
+	for _, in := range []string{
+		"08/21/71",
+		"8/1/71",
+		"08.21.71",
+		"4/8/14 22:05",
+		"oct 7, '70",
+		"7 oct 70",
+		"13-Feb-03",
+		"Mon, 02 Jan 06 15:04:05 -0700",
+		"Monday, 02-Jan-06 15:04:05 MST",
+		// RFC 850
+		"Monday, 02-Jan-06 15:04:05 -0700",
+		"Sunday, 06-Nov-94 08:49:37 GMT",
+		"Mon, 02-Jan-06 15:04:05 GMT",
+	} {
+		_, err := ParseAny(in)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		_, err = ParseAny(in, WithRequireFourDigitYear(true))
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+
+	for _, in := range []string{
+		"08/21/1971",
+		"08.21.1971",
+		"oct 7, 1970",
+		"Mon, 02 Jan 2006 15:04:05 -0700",
+		"Monday, 02-Jan-2006 15:04:05 MST",
+		"Sunday, 06-Nov-1994 08:49:37 GMT",
+		"2006-01-02T15:04:05Z",
+		"20140601",
+		"1332151919",
+	} {
+		_, err := ParseAny(in, WithRequireFourDigitYear(true))
+		assert.Equal(t, nil, err, "for in=%v", in)
+	}
+}
+
+func TestAssumeCentury(t *testing.T) {
+	base := time.Date(2014, 6, 1, 0, 0, 0, 0, time.UTC)
+	for _, th := range []dateTest{
+		{in: "04/26/214", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "04/26/214 17:24:37", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "26 Apr 214", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "April 26, 214 17:24", out: "2014-04-26 17:24:00 +0000 UTC"},
+		{in: "04/26/201", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "04/26/14", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "04/26/99", out: "1999-04-26 00:00:00 +0000 UTC"},
+		{in: "04/26/31", out: "2031-04-26 00:00:00 +0000 UTC"},
+		{in: "04/26/69", out: "1969-04-26 00:00:00 +0000 UTC"},
+		{in: "04/26/7", out: "2017-04-26 00:00:00 +0000 UTC"},
+		{in: "04/26/2014", out: "2014-04-26 00:00:00 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, WithAssumeCentury(true), WithBaseDate(base))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	// the nearest year moves with the base date
+	ts, err := ParseAny("04/26/99", WithAssumeCentury(true), WithBaseDate(time.Date(2080, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2099-04-26 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts))
+
+	assert.Equal(t, 2014, centuryYear(2014, "214"))
+	assert.Equal(t, 2012, centuryYear(2014, "22"))
+	assert.Equal(t, 2009, centuryYear(2014, "9")) // the earlier of 2009 and 2019
+
+	// without the option 3 digit years are an error
+	_, err = ParseAny("04/26/214")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestHeuristicsDisabled(t *testing.T) {
+	RegisterLayout("2006.01.02 AD at 15:04:05")
+	defer func() { registeredLayouts = nil }()
+
+	for _, th := range []dateTest{
+		{in: "2014.04.26 AD at 17:24:37", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "2014-04-26T17:24:37Z", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "2014-04-26T17:24:37.123-07:00", out: "2014-04-27 00:24:37.123 +0000 UTC"},
+		{in: "2014-04-26", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "20140426", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "2014-W17-6", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "2014-116", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "Sat, 26 Apr 2014 17:24:37 -0700", out: "2014-04-27 00:24:37 +0000 UTC"},
+		{in: "Sat, 26 Apr 2014 17:24:37 UTC", out: "2014-04-26 17:24:37 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, WithHeuristicsDisabled(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	for _, in := range []string{
+		"01/02/2014",
+		"02/13/2014",
+		"1332151919",
+		"April 26, 2014",
+		"2014-04-26 17:24:37",
+		"04.26.2014",
+	} {
+		_, err := ParseAny(in)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		_, err = ParseAny(in, WithHeuristicsDisabled(true))
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+
+	// a registered layout is tried first, in the given location
+	denverLoc, _ := time.LoadLocation("America/Denver")
+	ts, err := ParseIn("2014.04.26 AD at 17:24:37", denverLoc)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 17:24:37 -0600 MDT", ts.String())
+	layout, err := ParseFormat("2014.04.26 AD at 17:24:37")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2006.01.02 AD at 15:04:05", layout)
+}
+
+func TestTimeFirst(t *testing.T) {
+	for _, th := range []dateTest{
+		{in: "at 5:24 PM on April 26, 2014", out: "2014-04-26 17:24:00 +0000 UTC"},
+		{in: "at 5:24 pm on Apr 26, 2014", out: "2014-04-26 17:24:00 +0000 UTC"},
+		{in: "at 5:24:37 AM on April 26, 2014", out: "2014-04-26 05:24:37 +0000 UTC"},
+		{in: "at 17:24 on April 26, 2014", out: "2014-04-26 17:24:00 +0000 UTC"},
+		{in: "At 17:24:37 on 2014-04-26", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "at 17:24 April 26, 2014", out: "2014-04-26 17:24:00 +0000 UTC"},
+		{in: "at 5:24 PM 04/26/2014", out: "2014-04-26 17:24:00 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, EnableRelative(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	for _, in := range []string{
+		"at 17:24 on April 31, 2014",
+		"at 17:24 on 13/26/2014",
+		"at 25:24 on April 26, 2014",
+		"at 17:24 on",
+		"at noon on April 26, 2014",
+	} {
+		_, err := ParseAny(in, EnableRelative(true))
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+
+	// only with relative expressions enabled
+	_, err := ParseAny("at 17:24 on April 26, 2014")
+	assert.NotEqual(t, nil, err)
+
+	// the layout is time first, as the date-string
+	for _, th := range []struct {
+		in, layout string
+	}{
+		{in: "at 5:24 PM on April 26, 2014", layout: "at 3:04 PM on January 02, 2006"},
+		{in: "at 17:24:37 on 2014-04-26", layout: "at 15:04:05 on 2006-01-02"},
+		{in: "at 17:24 April 26, 2014", layout: "at 15:04 January 02, 2006"},
+	} {
+		ts, layout, err := ParseAnyWithFormat(th.in, EnableRelative(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.layout, layout, "for in=%v", th.in)
+		again, err := time.Parse(layout, th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.True(t, ts.Equal(again), "for in=%v", th.in)
+	}
+}
+
+func TestMilitaryTime(t *testing.T) {
+	base := time.Date(2014, time.April, 26, 8, 0, 0, 0, time.UTC)
+	for _, th := range []dateTest{
+		{in: "1430 hrs", out: "2014-04-26 14:30:00 +0000 UTC"},
+		{in: "1430 HRS", out: "2014-04-26 14:30:00 +0000 UTC"},
+		{in: "0000 hrs", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "1430Z", out: "2014-04-26 14:30:00 +0000 UTC"},
+		{in: "0900A", out: "2014-04-26 08:00:00 +0000 UTC"},
+		{in: "0900M", out: "2014-04-25 21:00:00 +0000 UTC"},
+		{in: "0900N", out: "2014-04-26 10:00:00 +0000 UTC"},
+		{in: "2359Y", out: "2014-04-27 11:59:00 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, EnableRelative(true), WithBaseDate(base))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	// hrs are in the parse location
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	ts, err := ParseIn("1430 hrs", denverLoc, EnableRelative(true), WithBaseDate(base))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 14:30:00 -0600 MDT", ts.String())
+	ts, err = ParseIn("1430Z", denverLoc, EnableRelative(true), WithBaseDate(base))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 14:30:00 +0000 UTC", ts.String())
+
+	for _, in := range []string{"2430 hrs", "1460Z", "1430J", "1430 ZZ", "14300Z"} {
+		_, err := ParseAny(in, EnableRelative(true), WithBaseDate(base))
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+
+	// only with relative expressions enabled
+	_, err = ParseAny("1430Z")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestEightDigitOrder(t *testing.T) {
+	for _, th := range []struct {
+		in    string
+		order FieldOrder
+		out   string
+	}{
+		{in: "20140601", order: OrderYMD, out: "2014-06-01 00:00:00 +0000 UTC"},
+		{in: "01062014", order: OrderDMY, out: "2014-06-01 00:00:00 +0000 UTC"},
+		{in: "06012014", order: OrderMDY, out: "2014-06-01 00:00:00 +0000 UTC"},
+		{in: "31122014", order: OrderDMY, out: "2014-12-31 00:00:00 +0000 UTC"},
+		// the other lengths are unchanged
+		{in: "20140601172437", order: OrderDMY, out: "2014-06-01 17:24:37 +0000 UTC"},
+		{in: "1332151919", order: OrderDMY, out: "2012-03-19 10:11:59 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, WithEightDigitOrder(th.order))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	// the fields are validated
+	for _, th := range []struct {
+		in    string
+		order FieldOrder
+	}{
+		{in: "01062014", order: OrderYMD},
+		{in: "20140601", order: OrderDMY},
+		{in: "31062014", order: OrderDMY},
+		{in: "13012014", order: OrderMDY},
+	} {
+		_, err := ParseAny(th.in, WithEightDigitOrder(th.order))
+		assert.NotEqual(t, nil, err, "for in=%v", th.in)
+	}
+
+	// yyyymmdd by default
+	ts, err := ParseAny("20140601")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-06-01 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts))
+
+	_, err = ParseAny("20140601", WithEightDigitOrder(FieldOrder(9)))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestWeekDateSeparators(t *testing.T) {
+	for _, in := range []string{"2014-W15-2", "2014W15-2", "2014W152"} {
+		ts, err := ParseAny(in)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		assert.Equal(t, "2014-04-08 00:00:00 +0000 UTC", ts.String(), "for in=%v", in)
+
+		ts, err = ParseAny(in + "T09:00:00+01:00")
+		assert.Equal(t, nil, err, "for in=%v", in)
+		assert.Equal(t, "2014-04-08 08:00:00 +0000 UTC", ts.In(time.UTC).String(), "for in=%v", in)
+	}
+}
+
+func TestCompactYearMonth(t *testing.T) {
+	for _, th := range []dateTest{
+		{in: "201404", out: "2014-04-01 00:00:00 +0000 UTC"},
+		{in: "199912", out: "1999-12-01 00:00:00 +0000 UTC"},
+		{in: "201401", out: "2014-01-01 00:00:00 +0000 UTC"},
+		// the other lengths are unchanged
+		{in: "20140426", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "2014", out: "2014-01-01 00:00:00 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, WithCompactYearMonth(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	for _, in := range []string{"201413", "201400", "2014041"} {
+		_, err := ParseAny(in, WithCompactYearMonth(true))
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+
+	// six digits are not a year and month by default
+	_, err := ParseAny("201404")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestDayOfYear(t *testing.T) {
+	for _, th := range []dateTest{
+		{in: "Day 103 of 2014", out: "2014-04-13 00:00:00 +0000 UTC"},
+		{in: "Day 103 of 2014 14:30", out: "2014-04-13 14:30:00 +0000 UTC"},
+		{in: "day 103 of 2014 14:30:15", out: "2014-04-13 14:30:15 +0000 UTC"},
+		{in: "Day 1 of 2014", out: "2014-01-01 00:00:00 +0000 UTC"},
+		{in: "Day 365 of 2014", out: "2014-12-31 00:00:00 +0000 UTC"},
+		{in: "Day 366 of 2016 08:00", out: "2016-12-31 08:00:00 +0000 UTC"},
+		{in: "Day 060 of 2016", out: "2016-02-29 00:00:00 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, EnableRelative(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	for _, in := range []string{
+		"Day 366 of 2014",
+		"Day 0 of 2014",
+		"Day 103 of 14",
+		"Day x of 2014",
+		"Day 103 of 2014 25:30",
+		"Day 103 of 2014 14:30 PM",
+		"Day 103 of 2014 noon",
+	} {
+		_, err := ParseAny(in, EnableRelative(true))
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+
+	// only with relative expressions enabled
+	_, err := ParseAny("Day 103 of 2014 14:30")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestMonthBoundary(t *testing.T) {
+	for _, th := range []dateTest{
+		{in: "2014-04 EOM", out: "2014-04-30 00:00:00 +0000 UTC"},
+		{in: "2014-05 EOM", out: "2014-05-31 00:00:00 +0000 UTC"},
+		{in: "2016-02 EOM", out: "2016-02-29 00:00:00 +0000 UTC"},
+		{in: "2014-02 EOM", out: "2014-02-28 00:00:00 +0000 UTC"},
+		{in: "2014-04 BOM", out: "2014-04-01 00:00:00 +0000 UTC"},
+		{in: "2014-04-26 eom", out: "2014-04-30 00:00:00 +0000 UTC"},
+		{in: "2014-04-26 17:24:37 BOM", out: "2014-04-01 17:24:37 +0000 UTC"},
+		{in: "April 2014 EOM", out: "2014-04-30 00:00:00 +0000 UTC"},
+		{in: "2014-12 EOM", out: "2014-12-31 00:00:00 +0000 UTC"},
+		{in: "2014-04 EOM", out: "2014-04-30 06:00:00 +0000 UTC", loc: "America/Denver"},
+	} {
+		var ts time.Time
+		var err error
+		if th.loc != "" {
+			loc, _ := time.LoadLocation(th.loc)
+			ts, err = ParseIn(th.in, loc, EnableRelative(true))
+		} else {
+			ts, err = ParseAny(th.in, EnableRelative(true))
+		}
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	_, err := ParseAny("EOM", EnableRelative(true))
+	assert.NotEqual(t, nil, err)
+	_, err = ParseAny("2014-13 EOM", EnableRelative(true))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestWeekDate(t *testing.T) {
+	for _, th := range []struct {
+		in, out string
+		start   time.Weekday
+	}{
+		{in: "Week 15 2014 Monday", out: "2014-04-07 00:00:00 +0000 UTC", start: time.Monday},
+		{in: "Week 15 2014 Sunday", out: "2014-04-13 00:00:00 +0000 UTC", start: time.Monday},
+		{in: "week 15 2014 sat", out: "2014-04-12 00:00:00 +0000 UTC", start: time.Monday},
+		{in: "Week 1 2014 Monday", out: "2013-12-30 00:00:00 +0000 UTC", start: time.Monday},
+		{in: "Week 53 2015 Sunday", out: "2016-01-03 00:00:00 +0000 UTC", start: time.Monday},
+		{in: "Week 15 2014 Monday", out: "2014-04-07 00:00:00 +0000 UTC", start: time.Sunday},
+		{in: "Week 15 2014 Sunday", out: "2014-04-06 00:00:00 +0000 UTC", start: time.Sunday},
+		{in: "Week 1 2014 Saturday", out: "2014-01-04 00:00:00 +0000 UTC", start: time.Sunday},
+		{in: "Week 53 2014 Sunday", out: "2014-12-28 00:00:00 +0000 UTC", start: time.Sunday},
+	} {
+		ts, err := ParseAny(th.in, WithWeekStart(th.start))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	// the default is the ISO week
+	ts, err := ParseAny("Week 15 2014 Sunday")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-13 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts))
+
+	for _, in := range []string{
+		"Week 0 2014 Monday",
+		"Week 53 2014 Monday",
+		"Week 54 2015 Monday",
+		"Week 15 2014 Funday",
+		"Week 15 14 Monday",
+		"Week fifteen 2014 Monday",
+		"Week 15 2014",
+	} {
+		_, err := ParseAny(in)
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+
+	_, err = ParseAny("Week 15 2014 Monday", WithWeekStart(time.Weekday(7)))
+	assert.NotEqual(t, nil, err)
+
+	// other date-strings starting with week are left to the lexer
+	_, err = ParseAny("Week 15 2014")
+	assert.Equal(t, `Could not find format for "Week 15 2014"`, err.Error())
+}
+
+func TestBaseDateYear(t *testing.T) {
+	base := time.Date(2014, time.January, 15, 0, 0, 0, 0, time.UTC)
+	for _, th := range []struct {
+		in, out  string
+		dayFirst bool
+	}{
+		{in: "04/26", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "4/26", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "04-26", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "04/26 17:24", out: "2014-04-26 17:24:00 +0000 UTC"},
+		{in: "04-26 17:24:37", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "26/04", out: "2014-04-26 00:00:00 +0000 UTC", dayFirst: true},
+		{in: "26-04", out: "2014-04-26 00:00:00 +0000 UTC", dayFirst: true},
+		{in: "26/04 17:24", out: "2014-04-26 17:24:00 +0000 UTC", dayFirst: true},
+		{in: "04/02", out: "2014-02-04 00:00:00 +0000 UTC", dayFirst: true},
+		{in: "26/04", out: "2014-04-26 00:00:00 +0000 UTC"},
+		// a year is kept
+		{in: "04/26/2015", out: "2015-04-26 00:00:00 +0000 UTC"},
+		{in: "04-26-2015", out: "2015-04-26 00:00:00 +0000 UTC"},
+		{in: "26-04-2015 17:24", out: "2015-04-26 17:24:00 +0000 UTC", dayFirst: true},
+	} {
+		ts, err := ParseAny(th.in, WithBaseDate(base), PreferDayFirst(th.dayFirst))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	ts, err := ParseIn("04/26 17:24", denverLoc, WithBaseDate(base))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 23:24:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// the default base date is now
+	SetNowFunc(func() time.Time { return time.Date(2015, time.December, 31, 23, 59, 59, 0, time.UTC) })
+	defer SetNowFunc(nil)
+	ts, err = ParseAny("04/26")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2015, ts.Year())
+
+	for _, in := range []string{
+		"02/29",
+		"04/31",
+		"04-26-",
+		"04/26/",
+	} {
+		_, err := ParseAny(in, WithBaseDate(base))
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+	_, err = ParseAny("02/29", WithBaseDate(time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, nil, err)
+}
+
+func TestPrecision(t *testing.T) {
+	for _, th := range []struct {
+		in, out   string
+		precision time.Duration
+		mode      PrecisionMode
+	}{
+		{in: "2009-08-12T22:15:09.6Z", out: "2009-08-12 22:15:09 +0000 UTC", precision: time.Second},
+		{in: "2009-08-12T22:15:09.6Z", out: "2009-08-12 22:15:09 +0000 UTC", precision: time.Second, mode: Truncate},
+		{in: "2009-08-12T22:15:09.6Z", out: "2009-08-12 22:15:10 +0000 UTC", precision: time.Second, mode: Round},
+		{in: "2009-08-12T22:15:09.5Z", out: "2009-08-12 22:15:10 +0000 UTC", precision: time.Second, mode: Round},
+		{in: "2009-08-12T22:15:09.499999999Z", out: "2009-08-12 22:15:09 +0000 UTC", precision: time.Second, mode: Round},
+		{in: "2009-08-12T22:15:59.5Z", out: "2009-08-12 22:16:00 +0000 UTC", precision: time.Second, mode: Round},
+		{in: "2009-08-12T22:15:09.123456Z", out: "2009-08-12 22:15:09.123 +0000 UTC", precision: time.Millisecond},
+		{in: "2009-08-12T22:15:09.123556Z", out: "2009-08-12 22:15:09.124 +0000 UTC", precision: time.Millisecond, mode: Round},
+		{in: "2009-08-12T22:15:30Z", out: "2009-08-12 22:16:00 +0000 UTC", precision: time.Minute, mode: Round},
+		{in: "2009-08-12T22:15:29Z", out: "2009-08-12 22:15:00 +0000 UTC", precision: time.Minute, mode: Round},
+		{in: "2009-08-12T22:15:09.6Z", out: "2009-08-12 22:15:09.6 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, WithPrecision(th.precision), WithPrecisionMode(th.mode))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	// the mode may be given before the precision
+	ts, err := ParseAny("2009-08-12T22:15:09.6Z", WithPrecisionMode(Round), WithPrecision(time.Second))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2009-08-12 22:15:10 +0000 UTC", fmt.Sprintf("%v", ts))
+}
+
+func TestParseSyslog(t *testing.T) {
+	base := time.Date(2003, time.October, 20, 0, 0, 0, 0, time.UTC)
+	for _, th := range []dateTest{
+		// RFC 5424
+		{in: "1985-04-12T23:20:50.52Z", out: "1985-04-12 23:20:50.52 +0000 UTC"},
+		{in: "1985-04-12T19:20:50.52-04:00", out: "1985-04-12 23:20:50.52 +0000 UTC"},
+		{in: "2003-10-11T22:14:15.003Z", out: "2003-10-11 22:14:15.003 +0000 UTC"},
+		{in: "2003-08-24T05:14:15.000003-07:00", out: "2003-08-24 12:14:15.000003 +0000 UTC"},
+		{in: "2003-10-11T22:14:15Z", out: "2003-10-11 22:14:15 +0000 UTC"},
+		// RFC 3164
+		{in: "Oct 11 22:14:15", out: "2003-10-11 22:14:15 +0000 UTC"},
+		{in: "Aug 24 05:34:00", out: "2003-08-24 05:34:00 +0000 UTC"},
+		{in: "Feb  5 17:32:18", out: "2003-02-05 17:32:18 +0000 UTC"},
+		{in: "Nov 19 23:59:59", out: "2003-11-19 23:59:59 +0000 UTC"},
+		// more than a month ahead is last year
+		{in: "Dec 31 23:59:59", out: "2002-12-31 23:59:59 +0000 UTC"},
+		// invalid
+		{in: "", err: true},
+		{in: "-", err: true},
+		{in: "2003-08-24T05:14:15.0000003-07:00", err: true},
+		{in: "2003-08-24 05:14:15Z", err: true},
+		{in: "2003-08-24T05:14:15", err: true},
+		{in: "Oct 11 22:14", err: true},
+		{in: "Oct 11 2003 22:14:15", err: true},
+		{in: "Feb 29 22:14:15", err: true},
+	} {
+		ts, err := ParseSyslog(th.in, WithBaseDate(base))
+		if th.err {
+			assert.NotEqual(t, nil, err, "for in=%v", th.in)
+		} else {
+			assert.Equal(t, nil, err, "for in=%v", th.in)
+			assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+		}
+	}
+
+	ts, err := ParseSyslog("Jan  2 00:00:00", WithBaseDate(time.Date(2004, time.January, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2004-01-02 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts))
+
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	ts, err = ParseSyslog("Oct 11 22:14:15", WithBaseDate(base), WithLocation(denverLoc))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2003-10-12 04:14:15 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// the year defaults to the current one
+	now := time.Now()
+	ts, err = ParseSyslog(now.Format("Jan _2 15:04:05"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, now.Year(), ts.Year())
+}
+
+func TestAllowedSeparators(t *testing.T) {
+	for _, in := range []string{
+		"2014-04-26",
+		"2014/04/26",
+		"04/26/2014 17:24:37",
+		"26-Apr-2014",
+		"2009-08-12T22:15:09Z",
+		"Apr 26, 2014",
+		"1332151919",
+	} {
+		_, err := ParseAny(in, WithAllowedSeparators('-', '/'))
+		assert.Equal(t, nil, err, "for in=%v", in)
+	}
+
+	for _, in := range []string{
+		"04.26.2014",
+		"3.31.2014",
+		"2014.04.26",
+		"08.21.71",
+	} {
+		_, err := ParseAny(in)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		_, err = ParseAny(in, WithAllowedSeparators('-', '/'))
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+
+	_, err := ParseAny("2014/04/26", WithAllowedSeparators('-'))
+	assert.NotEqual(t, nil, err)
+	_, err = ParseAny("2014-04-26", WithAllowedSeparators('/'))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestRelativeWeekday(t *testing.T) {
+	// a Wednesday
+	base := time.Date(2014, time.April, 23, 17, 24, 37, 0, time.UTC)
+	for _, th := range []struct {
+		in, out   string
+		direction WeekdayDirection
+	}{
+		{in: "Friday", out: "2014-04-25 00:00:00 +0000 UTC", direction: Next},
+		{in: "Monday", out: "2014-04-28 00:00:00 +0000 UTC", direction: Next},
+		{in: "Wednesday", out: "2014-04-30 00:00:00 +0000 UTC", direction: Next},
+		{in: "fri", out: "2014-04-25 00:00:00 +0000 UTC", direction: Next},
+		{in: "Friday", out: "2014-04-18 00:00:00 +0000 UTC", direction: Previous},
+		{in: "Monday", out: "2014-04-21 00:00:00 +0000 UTC", direction: Previous},
+		{in: "Wednesday", out: "2014-04-16 00:00:00 +0000 UTC", direction: Previous},
+		{in: "Friday", out: "2014-04-25 00:00:00 +0000 UTC", direction: Nearest},
+		{in: "Monday", out: "2014-04-21 00:00:00 +0000 UTC", direction: Nearest},
+		{in: "Saturday", out: "2014-04-26 00:00:00 +0000 UTC", direction: Nearest},
+		{in: "Sunday", out: "2014-04-20 00:00:00 +0000 UTC", direction: Nearest},
+		{in: "Wednesday", out: "2014-04-23 00:00:00 +0000 UTC", direction: Nearest},
+	} {
+		ts, err := ParseAny(th.in, EnableRelative(true), WithBaseDate(base), WithWeekdayDirection(th.direction))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	// next is the default
+	ts, err := ParseAny("Friday", EnableRelative(true), WithBaseDate(base))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-25 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts))
+
+	// midnight in the parse location
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	ts, err = ParseIn("Friday", denverLoc, EnableRelative(true), WithBaseDate(base))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-25 06:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// only with relative expressions enabled
+	_, err = ParseAny("Friday", WithBaseDate(base))
+	assert.NotEqual(t, nil, err)
+	_, err = ParseAny("Fryday", EnableRelative(true), WithBaseDate(base))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestWeekdayOrdinal(t *testing.T) {
+	base := time.Date(2014, time.April, 23, 17, 24, 37, 0, time.UTC)
+	for _, th := range []dateTest{
+		{in: "Saturday the 26th", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "Monday the 28th", out: "2014-04-28 00:00:00 +0000 UTC"},
+		{in: "tuesday the 1st", out: "2014-04-01 00:00:00 +0000 UTC"},
+		{in: "Wed, the 2nd", out: "2014-04-02 00:00:00 +0000 UTC"},
+		{in: "Thursday the 3rd", out: "2014-04-03 00:00:00 +0000 UTC"},
+		{in: "Friday the 11th", out: "2014-04-11 00:00:00 +0000 UTC"},
+		{in: "Tuesday the 22nd", out: "2014-04-22 00:00:00 +0000 UTC"},
+	} {
+		for _, validate := range []bool{false, true} {
+			ts, err := ParseAny(th.in, EnableRelative(true), WithBaseDate(base), ValidateWeekday(validate))
+			assert.Equal(t, nil, err, "for in=%v", th.in)
+			assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+		}
+	}
+
+	// the weekday is only checked when asked
+	ts, err := ParseAny("Monday the 26th", EnableRelative(true), WithBaseDate(base))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts))
+	_, err = ParseAny("Monday the 26th", EnableRelative(true), WithBaseDate(base), ValidateWeekday(true))
+	assert.NotEqual(t, nil, err)
+
+	for _, in := range []string{"Monday the 31st", "Monday the 26st", "Monday the 11st", "Monday the xth"} {
+		_, err := ParseAny(in, EnableRelative(true), WithBaseDate(base))
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+
+	// only with relative expressions enabled
+	_, err = ParseAny("Saturday the 26th", WithBaseDate(base))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestZoneConflictCheck(t *testing.T) {
+	for _, th := range []dateTest{
+		{in: "2016-06-21T19:55:00+01:00 BST", out: "2016-06-21 18:55:00 +0000 UTC"},
+		{in: "2016-06-21T19:55:00.799+01:00 BST", out: "2016-06-21 18:55:00.799 +0000 UTC"},
+		{in: "2016-06-21T19:55:00+0100 BST", out: "2016-06-21 18:55:00 +0000 UTC"},
+		{in: "2016-06-21T19:55:00Z UTC", out: "2016-06-21 19:55:00 +0000 UTC"},
+		{in: "2016-06-21T19:55:00.123Z GMT", out: "2016-06-21 19:55:00.123 +0000 UTC"},
+		{in: "2016-06-21 19:55:00 +01:00 BST", out: "2016-06-21 18:55:00 +0000 UTC"},
+		// unknown names are ignored
+		{in: "2016-06-21T19:55:00+01:00 XYZ", out: "2016-06-21 18:55:00 +0000 UTC"},
+		{in: "2016-06-21T19:55:00+05:00 BST", err: true},
+		{in: "2016-06-21T19:55:00+0500 BST", err: true},
+		{in: "2016-06-21T19:55:00Z BST", err: true},
+		{in: "2016-06-21T19:55:00.123Z PST", err: true},
+		{in: "2016-06-21 19:55:00 -07:00 EST", err: true},
+	} {
+		ts, err := ParseAny(th.in, WithZoneConflictCheck(true))
+		if th.err {
+			assert.NotEqual(t, nil, err, "for in=%v", th.in)
+			// without the check the name is ignored
+			_, err = ParseAny(th.in)
+			assert.Equal(t, nil, err, "for in=%v", th.in)
+		} else {
+			assert.Equal(t, nil, err, "for in=%v", th.in)
+			assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+		}
+	}
+
+	ts, err := ParseAny("2016-06-21T19:55:00+01:00 BST", WithPreserveZoneName(true))
+	assert.Equal(t, nil, err)
+	zone, offset := ts.Zone()
+	assert.Equal(t, "BST", zone)
+	assert.Equal(t, 3600, offset)
+}
+
+func TestSetNowFunc(t *testing.T) {
+	// a Wednesday
+	now := time.Date(2014, time.April, 23, 17, 24, 37, 0, time.UTC)
+	SetNowFunc(func() time.Time { return now })
+	defer SetNowFunc(nil)
+
+	for _, th := range []dateTest{
+		{in: "now", out: "2014-04-23 17:24:37 +0000 UTC"},
+		{in: "today", out: "2014-04-23 00:00:00 +0000 UTC"},
+		{in: "Yesterday", out: "2014-04-22 00:00:00 +0000 UTC"},
+		{in: "tomorrow", out: "2014-04-24 00:00:00 +0000 UTC"},
+		{in: "Friday", out: "2014-04-25 00:00:00 +0000 UTC"},
+		{in: "04/26", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "04/26 17:24", out: "2014-04-26 17:24:00 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, EnableRelative(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	ts, err := ParseSyslog("Dec 31 23:59:59")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2013-12-31 23:59:59 +0000 UTC", fmt.Sprintf("%v", ts))
+
+	// WithBaseDate takes precedence
+	base := time.Date(2016, time.January, 1, 12, 0, 0, 0, time.UTC)
+	for _, th := range []dateTest{
+		{in: "now", out: "2016-01-01 12:00:00 +0000 UTC"},
+		{in: "yesterday", out: "2015-12-31 00:00:00 +0000 UTC"},
+		{in: "Friday", out: "2016-01-08 00:00:00 +0000 UTC"},
+		{in: "02/29", out: "2016-02-29 00:00:00 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, EnableRelative(true), WithBaseDate(base))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	// midnight in the parse location
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	ts, err = ParseIn("today", denverLoc, EnableRelative(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-23 06:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// only with relative expressions enabled
+	_, err = ParseAny("today")
+	assert.NotEqual(t, nil, err)
+
+	// nil restores time.Now
+	SetNowFunc(nil)
+	ts, err = ParseAny("now", EnableRelative(true))
+	assert.Equal(t, nil, err)
+	assert.True(t, time.Since(ts) < time.Minute)
+}
+
+func TestMonthDayTime(t *testing.T) {
+	base := time.Date(2014, time.April, 30, 12, 0, 0, 0, time.UTC)
+	for _, th := range []dateTest{
+		{in: "Apr 26 17:24", out: "2014-04-26 17:24:00 +0000 UTC"},
+		{in: "Apr 26 17:24:37", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "Apr  6 17:24", out: "2014-04-06 17:24:00 +0000 UTC"},
+		{in: "April 26 9:05", out: "2014-04-26 09:05:00 +0000 UTC"},
+		{in: "May 30 17:24", out: "2014-05-30 17:24:00 +0000 UTC"},
+		// more than a month ahead is last year, as syslog
+		{in: "Dec 31 23:59", out: "2013-12-31 23:59:00 +0000 UTC"},
+		// router logs, day and month
+		{in: "26-Apr 17:24:37", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "26-Apr 17:24", out: "2014-04-26 17:24:00 +0000 UTC"},
+		{in: "6-Apr 17:24", out: "2014-04-06 17:24:00 +0000 UTC"},
+		{in: "31-Dec 23:59:59", out: "2013-12-31 23:59:59 +0000 UTC"},
+		// never after the base date
+		{in: "30-Apr 23:59", out: "2014-04-30 23:59:00 +0000 UTC"},
+		{in: "1-May 00:00", out: "2013-05-01 00:00:00 +0000 UTC"},
+		{in: "26-May 17:24", out: "2013-05-26 17:24:00 +0000 UTC"},
+		// the trailing number of a month and year is still the year
+		{in: "Apr 2014", out: "2014-04-01 00:00:00 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, WithBaseDate(base))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	ts, err := ParseIn("Apr 26 17:24", denverLoc, WithBaseDate(base))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 17:24:00 -0600 MDT", ts.String())
+
+	for _, in := range []string{"Apr 31 17:24", "Apr 26 25:24", "Apr 26 17:60", "Foo 26 17:24", "31-Apr 17:24", "26-Foo 17:24"} {
+		_, err := ParseAny(in, WithBaseDate(base))
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+
+	// the layout has no year, time.Parse gives the year 0
+	for _, th := range []struct {
+		in, layout string
+	}{
+		{in: "Apr 26 17:24", layout: "Jan 2 15:04"},
+		{in: "Apr 26 17:24:37", layout: "Jan 2 15:04:05"},
+		{in: "Apr  6 17:24", layout: "Jan  2 15:04"},
+		{in: "April 26 9:05", layout: "January 2 15:04"},
+		{in: "26-Apr 17:24:37", layout: "2-Jan 15:04:05"},
+		{in: "6-Apr 17:24", layout: "2-Jan 15:04"},
+	} {
+		layout, err := ParseFormat(th.in, WithBaseDate(base))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.layout, layout, "for in=%v", th.in)
+		ts, err := time.Parse(layout, th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		ts2, _ := ParseAny(th.in, WithBaseDate(base))
+		assert.Equal(t, ts2.AddDate(-ts2.Year(), 0, 0), ts, "for in=%v", th.in)
+	}
+}
+
+func TestClock(t *testing.T) {
+	// a Wednesday
+	clock := FixedClock(time.Date(2014, time.April, 23, 17, 24, 37, 0, time.UTC))
+	for _, th := range []dateTest{
+		{in: "now", out: "2014-04-23 17:24:37 +0000 UTC"},
+		{in: "today", out: "2014-04-23 00:00:00 +0000 UTC"},
+		{in: "yesterday", out: "2014-04-22 00:00:00 +0000 UTC"},
+		{in: "tomorrow", out: "2014-04-24 00:00:00 +0000 UTC"},
+		{in: "Friday", out: "2014-04-25 00:00:00 +0000 UTC"},
+		{in: "04/26", out: "2014-04-26 00:00:00 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, EnableRelative(true), WithClock(clock))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	age, err := ParseAge("2014-04-22 17:24:37", WithClock(clock))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 24*time.Hour, age)
+
+	// the clock supersedes SetNowFunc
+	SetNowFunc(func() time.Time { return time.Date(2016, time.January, 1, 12, 0, 0, 0, time.UTC) })
+	defer SetNowFunc(nil)
+	ts, err := ParseAny("yesterday", EnableRelative(true), WithClock(clock))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-22 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts))
+
+	// and WithBaseDate supersedes the clock
+	base := time.Date(2016, time.January, 1, 12, 0, 0, 0, time.UTC)
+	ts, err = ParseAny("now", EnableRelative(true), WithClock(clock), WithBaseDate(base))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2016-01-01 12:00:00 +0000 UTC", fmt.Sprintf("%v", ts))
+}
+
+func TestSevenDigitFraction(t *testing.T) {
+	for _, th := range []struct {
+		in   string
+		nsec int
+		iso  bool
+	}{
+		{in: "2014-04-26T17:24:37.0000001Z", nsec: 100, iso: true},
+		{in: "2014-04-26T17:24:37.0000010Z", nsec: 1000, iso: true},
+		{in: "2014-04-26T17:24:37.1234567+01:00", nsec: 123456700, iso: true},
+		{in: "2014-04-26T17:24:37.9999999-07:00", nsec: 999999900, iso: true},
+		{in: "2014-04-26 17:24:37.0000001", nsec: 100},
+		{in: "2014-04-26 17:24:37.0000001 +01:00", nsec: 100},
+	} {
+		ts, err := ParseAny(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.nsec, ts.Nanosecond(), "for in=%v", th.in)
+		if th.iso {
+			ts, err = ParseISO(th.in)
+			assert.Equal(t, nil, err, "for in=%v", th.in)
+			assert.Equal(t, th.nsec, ts.Nanosecond(), "for in=%v", th.in)
+		}
+	}
+
+	out, err := Normalize("2014-04-26T17:24:37.0000001Z", WithKeepFractionDigits(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26T17:24:37.0000001Z", out)
+}
+
+func TestParseAge(t *testing.T) {
+	base := time.Date(2014, time.April, 26, 17, 24, 37, 0, time.UTC)
+	for _, th := range []struct {
+		in  string
+		age time.Duration
+	}{
+		{in: "2014-04-26 17:24:37", age: 0},
+		{in: "2014-04-26 17:24:36.5", age: 500 * time.Millisecond},
+		{in: "2014-04-25 17:24:37", age: 24 * time.Hour},
+		{in: "2014-04-26T17:24:37+01:00", age: time.Hour},
+		{in: "1398533077", age: 0},
+		// the future is negative
+		{in: "2014-04-26 18:24:37", age: -time.Hour},
+		{in: "Apr 27, 2014 17:24:37", age: -24 * time.Hour},
+	} {
+		age, err := ParseAge(th.in, WithBaseDate(base))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.age, age, "for in=%v", th.in)
+	}
+
+	SetNowFunc(func() time.Time { return base })
+	defer SetNowFunc(nil)
+	age, err := ParseAge("2014-04-26 17:24:00")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 37*time.Second, age)
+
+	_, err = ParseAge("not a date", WithBaseDate(base))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestParseSliceFormats(t *testing.T) {
+	inputs := []string{
+		"2014-04-26 17:24:37",
+		"2014-04-27 08:01:02",
+		"04/28/2014 17:24",
+		"04/29/2014 09:00",
+		"2014-04-30 17:24:37",
+		"not a date",
+		"1332151919",
+		"May 1, 2014",
+	}
+	times, layouts, errs := ParseSliceFormats(inputs)
+	assert.Equal(t, len(inputs), len(times))
+	assert.Equal(t, []string{
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04:05",
+		"01/02/2006 15:04",
+		"01/02/2006 15:04",
+		"2006-01-02 15:04:05",
+		"",
+		"1332151919",
+		"Jan 2, 2006",
+	}, layouts)
+	for i, in := range inputs {
+		if in == "not a date" {
+			assert.NotEqual(t, nil, errs[i], "for in=%v", in)
+			continue
+		}
+		assert.Equal(t, nil, errs[i], "for in=%v", in)
+		// the same as parsing each on its own
+		want, err := ParseAny(in)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		assert.Equal(t, want.String(), times[i].String(), "for in=%v", in)
+	}
+
+	// the options apply to every row
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	times, errs = ParseSlice([]string{"2014-04-26 17:24:37", "2014-04-27 08:01:02"}, WithLocation(denverLoc))
+	assert.Equal(t, []error{nil, nil}, errs)
+	assert.Equal(t, "2014-04-26 23:24:37 +0000 UTC", fmt.Sprintf("%v", times[0].In(time.UTC)))
+	assert.Equal(t, "2014-04-27 14:01:02 +0000 UTC", fmt.Sprintf("%v", times[1].In(time.UTC)))
+
+	// a row the cached layout does not fit is detected again
+	times, layouts, errs = ParseSliceFormats([]string{"04/26/2014", "2014/04/27"})
+	assert.Equal(t, []error{nil, nil}, errs)
+	assert.Equal(t, []string{"01/02/2006", "2006/01/02"}, layouts)
+	assert.Equal(t, "2014-04-27 00:00:00 +0000 UTC", fmt.Sprintf("%v", times[1]))
+}
+
+func TestColumnInference(t *testing.T) {
+	// the second row only parses day first, so the first is day first too
+	inputs := []string{"01/02/2014", "13/02/2014", "03/04/2014 17:24"}
+	times, errs := ParseSlice(inputs, WithColumnInference(true))
+	assert.Equal(t, []error{nil, nil, nil}, errs)
+	assert.Equal(t, "2014-02-01 00:00:00 +0000 UTC", times[0].String())
+	assert.Equal(t, "2014-02-13 00:00:00 +0000 UTC", times[1].String())
+	assert.Equal(t, "2014-04-03 17:24:00 +0000 UTC", times[2].String())
+
+	// per row without it
+	times, errs = ParseSlice(inputs)
+	assert.Equal(t, []error{nil, nil, nil}, errs)
+	assert.Equal(t, "2014-01-02 00:00:00 +0000 UTC", times[0].String())
+	assert.Equal(t, "2014-02-13 00:00:00 +0000 UTC", times[1].String())
+	assert.Equal(t, "2014-03-04 17:24:00 +0000 UTC", times[2].String())
+
+	// a month first column is proven month first, even when day first is preferred
+	times, errs = ParseSlice([]string{"01/02/2014", "02/13/2014"}, PreferDayFirst(true), WithColumnInference(true))
+	assert.Equal(t, []error{nil, nil}, errs)
+	assert.Equal(t, "2014-01-02 00:00:00 +0000 UTC", times[0].String())
+
+	// rows proving both orders, or neither, leave the rows to their own order
+	for _, th := range []struct {
+		inputs []string
+		out    string
+	}{
+		{inputs: []string{"01/02/2014", "13/02/2014", "02/13/2014"}, out: "2014-01-02 00:00:00 +0000 UTC"},
+		{inputs: []string{"01/02/2014", "2014-02-13", "May 1, 2014"}, out: "2014-01-02 00:00:00 +0000 UTC"},
+	} {
+		times, errs := ParseSlice(th.inputs, WithColumnInference(true))
+		assert.Equal(t, nil, errs[0], "for inputs=%v", th.inputs)
+		assert.Equal(t, th.out, times[0].String(), "for inputs=%v", th.inputs)
+	}
+
+	// the formats follow the inferred order
+	_, layouts, errs := ParseSliceFormats([]string{"01/02/2014", "13/02/2014"}, WithColumnInference(true))
+	assert.Equal(t, []error{nil, nil}, errs)
+	assert.Equal(t, []string{"02/01/2006", "02/01/2006"}, layouts)
+}
+
+func TestParseElapsed(t *testing.T) {
+	for _, th := range []struct {
+		in string
+		d  time.Duration
+	}{
+		{in: "+01:23:45", d: time.Hour + 23*time.Minute + 45*time.Second},
+		{in: "01:23:45", d: time.Hour + 23*time.Minute + 45*time.Second},
+		{in: "-01:23:45", d: -(time.Hour + 23*time.Minute + 45*time.Second)},
+		{in: "+0:00:01", d: time.Second},
+		{in: "+00:00:00", d: 0},
+		{in: "+100:00:00", d: 100 * time.Hour},
+		{in: "+8760:30:00", d: 8760*time.Hour + 30*time.Minute},
+		{in: "+2562046:00:00", d: 2562046 * time.Hour},
+		{in: "+01:23:45.5", d: time.Hour + 23*time.Minute + 45*time.Second + 500*time.Millisecond},
+		{in: "+00:00:00.123", d: 123 * time.Millisecond},
+		{in: "+00:00:00.000000001", d: 1},
+		{in: "-100:00:00.25", d: -(100*time.Hour + 250*time.Millisecond)},
+	} {
+		d, err := ParseElapsed(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.d, d, "for in=%v", th.in)
+	}
+
+	for _, in := range []string{
+		"",
+		"+",
+		"+01:23",
+		"+01:23:45:00",
+		"+01:60:00",
+		"+01:00:60",
+		"+01:2:45",
+		"+01:23:45.",
+		"+01:23:45.1234567890",
+		"++01:23:45",
+		"+ab:23:45",
+		"+99999999:00:00",
+		"2014-04-26 17:24:37",
+	} {
+		_, err := ParseElapsed(in)
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+}
+
+func TestMonthYear(t *testing.T) {
+	// the 4 digit field is the year whatever the field order
+	for _, in := range []string{"2014/04", "04/2014", "04-2014"} {
+		ts, err := ParseStrict(in)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		assert.Equal(t, "2014-04-01 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts), "for in=%v", in)
+		ts, err = ParseAny(in, PreferDayFirst(true))
+		assert.Equal(t, nil, err, "for in=%v", in)
+		assert.Equal(t, "2014-04-01 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts), "for in=%v", in)
+	}
+
+	// month names have a layout that parses them again
+	for _, th := range []struct {
+		in, layout string
+	}{
+		{in: "April 2014", layout: "January 2006"},
+		{in: "Apr 2014", layout: "Jan 2006"},
+		{in: "Apr. 2014", layout: "Jan. 2006"},
+		{in: "2014 April", layout: "2006 January"},
+		{in: "2014 dec", layout: "2006 Jan"},
+		{in: "May 2014", layout: "Jan 2006"},
+	} {
+		layout, err := ParseFormat(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.layout, layout, "for in=%v", th.in)
+		ts, err := time.Parse(layout, th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		ts2, _ := ParseAny(th.in)
+		assert.Equal(t, ts2, ts, "for in=%v", th.in)
+	}
+}
+
+func TestPedanticRanges(t *testing.T) {
+	for _, th := range []struct {
+		in, field string
+	}{
+		{in: "2014-04-26 25:00:00", field: "hour"},
+		{in: "2014-04-26 17:60:00", field: "minute"},
+		{in: "2014-04-26 17:24:60", field: "second"},
+		{in: "2014-04-26 23:59:60", field: "second"},
+		{in: "2014-04-26T17:24:99Z", field: "second"},
+		{in: "04/26/2014 24:00", field: "hour"},
+		{in: "Apr 26, 2014 17:75", field: "minute"},
+	} {
+		_, err := ParseAny(th.in, WithPedanticRanges(true))
+		assert.NotEqual(t, nil, err, "for in=%v", th.in)
+		if err != nil {
+			assert.Contains(t, err.Error(), th.field, "for in=%v", th.in)
+		}
+		// the layout parse rejects them too, less precisely
+		_, err = ParseAny(th.in)
+		assert.NotEqual(t, nil, err, "for in=%v", th.in)
+	}
+
+	for _, in := range []string{
+		"2014-04-26 23:59:59",
+		"2014-04-26 00:00:00",
+		"2014-04-26T17:24:37.999Z",
+		"04/26/2014 5:24 PM",
+		"Apr 26, 2014 17:24",
+		"1332151919",
+		"2014-04-26",
+	} {
+		_, err := ParseAny(in, WithPedanticRanges(true))
+		assert.Equal(t, nil, err, "for in=%v", in)
+	}
+}
+
+func TestMonthDayYear24Hour(t *testing.T) {
+	for _, th := range []dateTest{
+		{in: "Feb 8, 2009 17:57:51", out: "2009-02-08 17:57:51 +0000 UTC"},
+		{in: "Feb 08, 2009 17:57:51", out: "2009-02-08 17:57:51 +0000 UTC"},
+		{in: "February 8, 2009 17:57:51", out: "2009-02-08 17:57:51 +0000 UTC"},
+		{in: "February 08, 2009 17:57:51", out: "2009-02-08 17:57:51 +0000 UTC"},
+		{in: "September 18, 2009 00:57:51", out: "2009-09-18 00:57:51 +0000 UTC"},
+		{in: "Feb 8, 2009 17:57:51.123", out: "2009-02-08 17:57:51.123 +0000 UTC"},
+		{in: "Feb 8, 2009 17:57:51 -0700", out: "2009-02-09 00:57:51 +0000 UTC"},
+		{in: "Feb 8, 2009 17:57:51 +01:00", out: "2009-02-08 16:57:51 +0000 UTC"},
+		{in: "February 8, 2009 17:57:51 -07:00", out: "2009-02-09 00:57:51 +0000 UTC"},
+		{in: "February 18, 2009 17:57:51 +0100", out: "2009-02-18 16:57:51 +0000 UTC"},
+		{in: "Sep 18, 2009 17:57:51 +01", out: "2009-09-18 16:57:51 +0000 UTC"},
+		{in: "Sep 18, 2009 17:57:51.123 -0700", out: "2009-09-19 00:57:51.123 +0000 UTC"},
+		{in: "Feb 8, 2009 17:57:51Z", out: "2009-02-08 17:57:51 +0000 UTC"},
+		{in: "February 8, 2009 17:57:51 UTC", out: "2009-02-08 17:57:51 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+}
+
+func TestSentinels(t *testing.T) {
+	maxTime := time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC)
+	sentinels := WithSentinels(map[string]time.Time{
+		"0000-00-00":          time.Time{},
+		"0000-00-00 00:00:00": time.Time{},
+		"9999-12-31":          maxTime,
+	})
+
+	// month and day zero do not parse
+	_, err := ParseAny("0000-00-00")
+	assert.NotEqual(t, nil, err)
+
+	for _, in := range []string{"0000-00-00", "0000-00-00 00:00:00"} {
+		ts, err := ParseAny(in, sentinels)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		assert.True(t, ts.IsZero(), "for in=%v", in)
+		ts, err = ParseIn(in, time.Local, sentinels)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		assert.True(t, ts.IsZero(), "for in=%v", in)
+	}
+
+	ts, err := ParseAny("9999-12-31", sentinels, WithPrecision(time.Second))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, maxTime, ts)
+
+	// other date-strings parse as usual
+	ts, err = ParseAny("9999-12-30", sentinels)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "9999-12-30 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts))
+	_, err = ParseAny("0000-00-01", sentinels)
+	assert.NotEqual(t, nil, err)
+
+	// the layout of a previous row is not used for a sentinel
+	times, errs := ParseSlice([]string{"2014-04-26", "9999-12-31", "0000-00-00"}, sentinels)
+	assert.Equal(t, []error{nil, nil, nil}, errs)
+	assert.Equal(t, "2014-04-26 00:00:00 +0000 UTC", fmt.Sprintf("%v", times[0]))
+	assert.Equal(t, maxTime, times[1])
+	assert.True(t, times[2].IsZero())
+}
+
+func TestYearBounds(t *testing.T) {
+	// a year of more than 4 digits is not a year
+	_, err := ParseAny("99999999-01-01")
+	assert.NotEqual(t, nil, err)
+	_, err = ParseAny("99999999-01-01", WithYearBounds(0, 0))
+	assert.NotEqual(t, nil, err)
+
+	// year 0 parses unless bounded, the default bounds are 1 to 9999
+	ts, err := ParseAny("0000-01-01")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, ts.Year())
+	_, err = ParseAny("0000-01-01", WithYearBounds(0, 0))
+	assert.NotEqual(t, nil, err)
+	ts, err = ParseAny("9999-12-31", WithYearBounds(0, 0))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 9999, ts.Year())
+
+	for _, th := range []dateTest{
+		{in: "2014-04-26", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "1900-01-01", out: "1900-01-01 00:00:00 +0000 UTC"},
+		{in: "2100-12-31 23:59:59", out: "2100-12-31 23:59:59 +0000 UTC"},
+		{in: "1899-12-31", err: true},
+		{in: "3014-04-26", err: true},
+		{in: "4133980800000", err: true},
+	} {
+		ts, err := ParseAny(th.in, WithYearBounds(1900, 2100))
+		if th.err {
+			assert.NotEqual(t, nil, err, "for in=%v", th.in)
+			continue
+		}
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts), "for in=%v", th.in)
+	}
+
+	_, err = ParseAny("2014-04-26", WithYearBounds(2100, 1900))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestDottedDayFirstTime(t *testing.T) {
+	for _, th := range []dateTest{
+		{in: "26.04.2014, 17:24", out: "2014-04-26 17:24:00 +0000 UTC"},
+		{in: "26.04.2014, 17:24:37", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "26.04.2014 17:24:37", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "6.4.2014, 7:24", out: "2014-04-06 07:24:00 +0000 UTC"},
+		{in: "26.04.14, 17:24", out: "2014-04-26 17:24:00 +0000 UTC"},
+		{in: "26.04.2014, 17:24:37", out: "2014-04-26 23:24:37 +0000 UTC", loc: "America/Denver"},
+	} {
+		var ts time.Time
+		var err error
+		if th.loc != "" {
+			loc, _ := time.LoadLocation(th.loc)
+			ts, err = ParseIn(th.in, loc, PreferDayFirst(true))
+		} else {
+			ts, err = ParseAny(th.in, PreferDayFirst(true))
+		}
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	// month first there is no 26th month, so it is day first
+	ts, err := ParseAny("26.04.2014, 17:24")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 17:24:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+	_, err = ParseAny("26.04.2014, 25:24", PreferDayFirst(true))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestDayFirstZoneName(t *testing.T) {
+	for _, th := range []dateTest{
+		{in: "26/04/2014 17:24:37 IST", out: "2014-04-26 11:54:37 +0000 UTC"},
+		{in: "26/04/2014 17:24:37 BST", out: "2014-04-26 16:24:37 +0000 UTC"},
+		{in: "26/04/2014 17:24:37 GMT", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "26/04/2014 17:24 IST", out: "2014-04-26 11:54:00 +0000 UTC"},
+		{in: "26/04/2014 17:24:37.123 IST", out: "2014-04-26 11:54:37.123 +0000 UTC"},
+		{in: "26/04/2014 17:24:37 BST", out: "2014-04-26 16:24:37 +0000 UTC", loc: "Europe/London"},
+		{in: "26/04/2014 17:24:37 IST", out: "2014-04-26 11:54:37 +0000 UTC", loc: "America/Denver"},
+	} {
+		var ts time.Time
+		var err error
+		if th.loc != "" {
+			loc, _ := time.LoadLocation(th.loc)
+			ts, err = ParseIn(th.in, loc, PreferDayFirst(true))
+		} else {
+			ts, err = ParseAny(th.in, PreferDayFirst(true))
+		}
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	ts, err := ParseAny("26/04/2014 17:24:37 IST", PreferDayFirst(true))
+	assert.Equal(t, nil, err)
+	name, offset := ts.Zone()
+	assert.Equal(t, "IST", name)
+	assert.Equal(t, 5*3600+1800, offset)
+
+	// a dayFirst language is day first too
+	ts, err = ParseAny("26/04/2014 17:24:37 BST", WithLanguage("fr"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 16:24:37 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// only day first, otherwise the name is text as to time.Parse
+	for _, th := range []struct {
+		in, out, wall string
+	}{
+		{in: "2014-04-26 17:24:37 IST", out: "2014-04-26 11:54:37 +0000 UTC", wall: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "2014-04-26 17:24:37 BST", out: "2014-04-26 16:24:37 +0000 UTC", wall: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "2014-04-26T17:24:37 CEST", out: "2014-04-26 15:24:37 +0000 UTC", wall: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "04/26/2014 17:24:37 JST", out: "2014-04-26 08:24:37 +0000 UTC", wall: "2014-04-26 17:24:37 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, PreferDayFirst(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+
+		for _, opts := range [][]ParserOption{nil, {PreferDayFirst(false)}} {
+			ts, layout, err := ParseAnyWithFormat(th.in, opts...)
+			assert.Equal(t, nil, err, "for in=%v", th.in)
+			assert.Equal(t, th.wall, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+			// the layout parses it to the same instant
+			again, err := time.Parse(layout, th.in)
+			assert.Equal(t, nil, err, "for in=%v", th.in)
+			assert.True(t, ts.Equal(again), "for in=%v layout=%v", th.in, layout)
+		}
+	}
+}
+
+func TestParseAnyWithFormat(t *testing.T) {
+	for _, th := range testInputs {
+		if th.loc != "" {
+			continue
+		}
+		ts, layout, err := ParseAnyWithFormat(th.in)
+		want, wantErr := ParseAny(th.in)
+		wantLayout, _ := ParseFormat(th.in)
+		assert.Equal(t, wantErr, err, "for in=%v", th.in)
+		assert.Equal(t, want, ts, "for in=%v", th.in)
+		assert.Equal(t, wantLayout, layout, "for in=%v", th.in)
+	}
+	for _, th := range testParseErrors {
+		_, layout, err := ParseAnyWithFormat(th.in)
+		assert.NotEqual(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, "", layout, "for in=%v", th.in)
+	}
+
+	ts, layout, err := ParseAnyWithFormat("26.04.2014, 17:24", PreferDayFirst(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 17:24:00 +0000 UTC", fmt.Sprintf("%v", ts))
+	assert.Equal(t, "02.01.2006, 15:04", layout)
+
+	// the layout of a zoned date-string parses it to the same instant
+	for _, in := range []string{
+		"Tue, 11 Jul 2017 16:28:13 +0200 (CEST)",
+		"Mon, 02 Jan 2006 15:04:05 MST",
+		"2014-04-26 17:24:37.123 -0700 PDT",
+		"2009-08-12T22:15:09-07:00",
+	} {
+		ts, layout, err := ParseAnyWithFormat(in)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		again, err := time.Parse(layout, in)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		assert.True(t, ts.Equal(again), "for in=%v layout=%v", in, layout)
+	}
+	_, layout, err = ParseAnyWithFormat("Tue, 11 Jul 2017 16:28:13 +0200 (CEST)")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Mon, 02 Jan 2006 15:04:05 -0700 (CEST)", layout)
+
+	// the layout keeps the comma after or before a month name
+	for _, th := range []struct {
+		in, layout string
+	}{
+		{in: "26 April, 2014", layout: "02 January, 2006"},
+		{in: "26 Apr, 2014", layout: "02 Jan, 2006"},
+		{in: "26, April 2014", layout: "02, January 2006"},
+		{in: "26, Apr 2014", layout: "02, Jan 2006"},
+	} {
+		ts, layout, err := ParseAnyWithFormat(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.layout, layout, "for in=%v", th.in)
+		again, err := time.Parse(layout, th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.True(t, ts.Equal(again), "for in=%v", th.in)
+	}
+
+	// the layout of a time first date-string is time first
+	for _, th := range []struct {
+		in, layout string
+	}{
+		{in: "17:24:37, Apr 26 2014", layout: "15:04:05, Jan 02 2006"},
+		{in: "17:24:37 Apr 26 2014", layout: "15:04:05 Jan 02 2006"},
+		{in: "17:24:37.123, Apr 26 2014", layout: "15:04:05.000, Jan 02 2006"},
+		{in: "5:24 PM, April 26 2014", layout: "3:04 PM, January 02 2006"},
+	} {
+		ts, layout, err := ParseAnyWithFormat(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.layout, layout, "for in=%v", th.in)
+		again, err := time.Parse(layout, th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.True(t, ts.Equal(again), "for in=%v", th.in)
+	}
+
+	// the day of the year layout parses it again
+	for _, th := range []struct {
+		in, layout string
+	}{
+		{in: "2014.103", layout: "2006.002"},
+		{in: "2016.366", layout: "2006.002"},
+		{in: "2014.13", layout: "2006.__2"},
+	} {
+		ts, layout, err := ParseAnyWithFormat(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.layout, layout, "for in=%v", th.in)
+		again, err := time.Parse(layout, th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, ts, again, "for in=%v", th.in)
+	}
+}
+
+func TestASCIIOnly(t *testing.T) {
+	for _, in := range []string{
+		"2014年04月08日",
+		"2014年04月08日 19:17:22",
+		"2014−04−08",
+		"26 avril 2014 17:24:37 +0200 (heure d’été)",
+	} {
+		_, err := ParseAny(in, WithASCIIOnly(true))
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+
+	ts, err := ParseAny("2014-04-08", WithASCIIOnly(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-08 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts))
+
+	// off by default
+	ts, err = ParseAny("2014年04月08日")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-08 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts))
+}
+
+func TestParseFields(t *testing.T) {
+	for _, th := range []struct {
+		in    string
+		wc    WallClock
+		zoned bool
+	}{
+		{in: "2014-04-26 17:24:37", wc: WallClock{2014, time.April, 26, 17, 24, 37, 0}},
+		{in: "2014-04-26 17:24:37.123456789", wc: WallClock{2014, time.April, 26, 17, 24, 37, 123456789}},
+		{in: "04/26/2014 5:24 PM", wc: WallClock{2014, time.April, 26, 17, 24, 0, 0}},
+		{in: "April 26, 2014", wc: WallClock{2014, time.April, 26, 0, 0, 0, 0}},
+		// the fields are as written, not moved to UTC
+		{in: "2014-04-26T17:24:37-07:00", wc: WallClock{2014, time.April, 26, 17, 24, 37, 0}, zoned: true},
+		{in: "2014-04-26 23:24:37 +0100", wc: WallClock{2014, time.April, 26, 23, 24, 37, 0}, zoned: true},
+		{in: "2014-04-26T17:24:37Z", wc: WallClock{2014, time.April, 26, 17, 24, 37, 0}, zoned: true},
+		{in: "Sat, 26 Apr 2014 17:24:37 MST", wc: WallClock{2014, time.April, 26, 17, 24, 37, 0}, zoned: true},
+		// the north american zone names time.Parse reads as UTC
+		{in: "Sat, 26 Apr 2014 17:24:37 PDT", wc: WallClock{2014, time.April, 26, 17, 24, 37, 0}, zoned: true},
+		{in: "2014-04-26 05:24:37 PST", wc: WallClock{2014, time.April, 26, 5, 24, 37, 0}, zoned: true},
+		{in: "Fri, 03 Jul 2015 08:08:08 EST", wc: WallClock{2015, time.July, 3, 8, 8, 8, 0}, zoned: true},
+		{in: "Mon Jan  2 15:04:05 MST 2006", wc: WallClock{2006, time.January, 2, 15, 4, 5, 0}, zoned: true},
+		{in: "2014-04-26 17:24:37 UTC", wc: WallClock{2014, time.April, 26, 17, 24, 37, 0}, zoned: true},
+		// epochs are UTC
+		{in: "1398533077", wc: WallClock{2014, time.April, 26, 17, 24, 37, 0}, zoned: true},
+		{in: "1398533077123", wc: WallClock{2014, time.April, 26, 17, 24, 37, 123000000}, zoned: true},
+	} {
+		wc, zoned, err := ParseFields(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.wc, wc, "for in=%v", th.in)
+		assert.Equal(t, th.zoned, zoned, "for in=%v", th.in)
+	}
+
+	// time.Local does not change the fields
+	local := time.Local
+	time.Local = time.FixedZone("", -11*60*60)
+	defer func() { time.Local = local }()
+	wc, zoned, err := ParseFields("1398533077")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, WallClock{2014, time.April, 26, 17, 24, 37, 0}, wc)
+	assert.True(t, zoned)
+
+	_, _, err = ParseFields("2014-13-26")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestParseFileTime(t *testing.T) {
+	for _, th := range []dateTest{
+		{in: "130430066771234567", out: "2014-04-26 17:24:37.1234567 +0000 UTC"},
+		{in: "01CF617465EE0F07", out: "2014-04-26 17:24:37.1234567 +0000 UTC"},
+		{in: "01cf617465ee0f07", out: "2014-04-26 17:24:37.1234567 +0000 UTC"},
+		{in: "0x01CF617465EE0F07", out: "2014-04-26 17:24:37.1234567 +0000 UTC"},
+		{in: "116444736000000000", out: "1970-01-01 00:00:00 +0000 UTC"},
+		{in: "019DB1DED53E8000", out: "1970-01-01 00:00:00 +0000 UTC"},
+		{in: "0", out: "1601-01-01 00:00:00 +0000 UTC"},
+		{in: "1", out: "1601-01-01 00:00:00.0000001 +0000 UTC"},
+	} {
+		ts, err := ParseFileTime(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts), "for in=%v", th.in)
+	}
+
+	for _, in := range []string{
+		"",
+		"0x",
+		"-1",
+		"+130430066771234567",
+		"01CF617465EE0F0G",
+		"0xFFFFFFFFFFFFFFFF",
+		"99999999999999999999",
+		"2014-04-26",
+	} {
+		_, err := ParseFileTime(in)
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
 }
 
-func TestParseErrors(t *testing.T) {
-	for _, th := range testParseErrors {
-		v, err := ParseAny(th.in)
-		assert.NotEqual(t, nil, err, "%v for %v", v, th.in)
+func TestParseRangeExpr(t *testing.T) {
+	for _, th := range []struct {
+		in, start, end string
+	}{
+		{in: "Jan-Mar 2014", start: "2014-01-01 00:00:00 +0000 UTC", end: "2014-04-01 00:00:00 +0000 UTC"},
+		{in: "Jan–Mar 2014", start: "2014-01-01 00:00:00 +0000 UTC", end: "2014-04-01 00:00:00 +0000 UTC"},
+		{in: "Jan – Mar 2014", start: "2014-01-01 00:00:00 +0000 UTC", end: "2014-04-01 00:00:00 +0000 UTC"},
+		{in: "April-June 2014", start: "2014-04-01 00:00:00 +0000 UTC", end: "2014-07-01 00:00:00 +0000 UTC"},
+		{in: "Oct-Dec 2014", start: "2014-10-01 00:00:00 +0000 UTC", end: "2015-01-01 00:00:00 +0000 UTC"},
+		{in: "Feb-Feb 2016", start: "2016-02-01 00:00:00 +0000 UTC", end: "2016-03-01 00:00:00 +0000 UTC"},
+	} {
+		start, end, err := ParseRangeExpr(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.start, start.String(), "for in=%v", th.in)
+		assert.Equal(t, th.end, end.String(), "for in=%v", th.in)
+	}
+
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	start, end, err := ParseRangeExpr("Jan-Mar 2014", WithLocation(denverLoc))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-01-01 00:00:00 -0700 MST", start.String())
+	assert.Equal(t, "2014-04-01 00:00:00 -0600 MDT", end.String())
+
+	start, end, err = ParseRangeExpr("janv.-mars 2014", WithLanguage("fr"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-01-01 00:00:00 +0000 UTC", start.String())
+	assert.Equal(t, "2014-04-01 00:00:00 +0000 UTC", end.String())
+
+	for _, in := range []string{
+		"Mar-Jan 2014",
+		"Jan-Foo 2014",
+		"Jan Mar 2014",
+		"Jan-Mar",
+		"Jan-Mar 14",
+		"Jan-Mar 2014 extra",
+		"",
+	} {
+		_, _, err := ParseRangeExpr(in)
+		assert.NotEqual(t, nil, err, "for in=%v", in)
 	}
 }
 
-var testParseFormat = []dateTest{
-	// errors
-	{in: "3", err: true},
-	{in: `{"hello"}`, err: true},
-	{in: "2009-15-12T22:15Z", err: true},
-	{in: "5,000-9,999", err: true},
-	//
-	{in: "oct 7, 1970", out: "Jan 2, 2006"},
-	{in: "sept. 7, 1970", out: "Jan. 2, 2006"},
-	{in: "May 05, 2015, 05:05:07", out: "Jan 02, 2006, 15:04:05"},
-	// 03 February 2013
-	{in: "03 February 2013", out: "02 January 2006"},
-	// 13:31:51.999 -07:00 MST
-	//   yyyy-mm-dd hh:mm:ss +00:00
-	{in: "2012-08-03 18:31:59 +00:00", out: "2006-01-02 15:04:05 -07:00"},
-	//   yyyy-mm-dd hh:mm:ss +0000 TZ
-	// Golang Native Format
-	{in: "2012-08-03 18:31:59 +0000 UTC", out: "2006-01-02 15:04:05 -0700 UTC"},
-	//   yyyy-mm-dd hh:mm:ss TZ
-	{in: "2012-08-03 18:31:59 UTC", out: "2006-01-02 15:04:05 UTC"},
-	//   yyyy-mm-ddThh:mm:ss-07:00
-	{in: "2009-08-12T22:15:09-07:00", out: "2006-01-02T15:04:05-07:00"},
-	//   yyyy-mm-ddThh:mm:ss-0700
-	{in: "2009-08-12T22:15:09-0700", out: "2006-01-02T15:04:05-0700"},
-	//   yyyy-mm-ddThh:mm:ssZ
-	{in: "2009-08-12T22:15Z", out: "2006-01-02T15:04Z"},
+func TestParseFiscalQuarter(t *testing.T) {
+	for _, th := range []struct {
+		in         string
+		fyStart    time.Month
+		start, end string
+	}{
+		// a july fiscal year, FY24 is July 2023 to June 2024
+		{"Q1 FY24", time.July, "2023-07-01", "2023-10-01"},
+		{"Q2 FY24", time.July, "2023-10-01", "2024-01-01"},
+		{"Q3 FY24", time.July, "2024-01-01", "2024-04-01"},
+		{"Q4 FY24", time.July, "2024-04-01", "2024-07-01"},
+		{"q2 fy2024", time.July, "2023-10-01", "2024-01-01"},
+		// a january fiscal year is the calendar year
+		{"Q1 FY24", time.January, "2024-01-01", "2024-04-01"},
+		{"Q2 FY24", time.January, "2024-04-01", "2024-07-01"},
+		{"Q4 FY24", time.January, "2024-10-01", "2025-01-01"},
+		{"Q4 FY99", time.January, "1999-10-01", "2000-01-01"},
+		// an october fiscal year wraps the calendar year in Q1
+		{"Q1 FY24", time.October, "2023-10-01", "2024-01-01"},
+		{"Q4 FY24", time.October, "2024-07-01", "2024-10-01"},
+	} {
+		start, end, err := ParseFiscalQuarter(th.in, th.fyStart)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.start, start.Format("2006-01-02"), "for in=%v start=%v", th.in, th.fyStart)
+		assert.Equal(t, th.end, end.Format("2006-01-02"), "for in=%v start=%v", th.in, th.fyStart)
+		assert.Equal(t, time.UTC, start.Location())
+	}
+
+	for _, in := range []string{
+		"Q0 FY24",
+		"Q5 FY24",
+		"Q2 2024",
+		"Q2 FY",
+		"Q2 FY024",
+		"Q12 FY24",
+		"FY24 Q2",
+		"Q2 FY24 extra",
+		"",
+	} {
+		_, _, err := ParseFiscalQuarter(in, time.July)
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+	_, _, err := ParseFiscalQuarter("Q2 FY24", time.Month(13))
+	assert.NotEqual(t, nil, err)
 }
 
-func TestParseLayout(t *testing.T) {
-	for _, th := range testParseFormat {
-		l, err := ParseFormat(th.in)
+func TestParseResult(t *testing.T) {
+	r, err := ParseResult("2014-04-26 17:24:37.123 -0700")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-27 00:24:37.123 +0000 UTC", fmt.Sprintf("%v", r.Time.In(time.UTC)))
+	assert.Equal(t, "2014-04-26 17:24:37.123 -0700", r.Input)
+	assert.Equal(t, "2006-01-02 15:04:05.000 -0700", r.Layout)
+	assert.Equal(t, WallClock{2014, time.April, 26, 17, 24, 37, 123000000}, r.Components.WallClock)
+	assert.True(t, r.Components.Zoned)
+
+	// the input as given, not as rewritten for the layout
+	r, err = ParseResult("26 avril 2014", WithLanguage("fr"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "26 avril 2014", r.Input)
+	assert.Equal(t, "02 January 2006", r.Layout)
+	assert.Equal(t, 26, r.Components.Day)
+	assert.False(t, r.Components.Zoned)
+
+	// the same as the separate functions
+	for _, in := range []string{"04/26/2014 5:24 PM", "1398533077", "Sat, 26 Apr 2014 17:24:37 MST"} {
+		r, err := ParseResult(in)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		ts, layout, err := ParseAnyWithFormat(in)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		assert.Equal(t, ts.String(), r.Time.String(), "for in=%v", in)
+		assert.Equal(t, layout, r.Layout, "for in=%v", in)
+		wc, zoned, err := ParseFields(in)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		assert.Equal(t, Components{WallClock: wc, Zoned: zoned}, r.Components, "for in=%v", in)
+	}
+
+	r, err = ParseResult("2014-13-26")
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, Result{}, r)
+}
+
+func TestParseBeats(t *testing.T) {
+	base := time.Date(2014, 4, 26, 17, 24, 37, 0, time.UTC)
+	for _, th := range []dateTest{
+		// @000 is midnight UTC+1
+		{in: "@000", out: "2014-04-25 23:00:00 +0000 UTC"},
+		{in: "@248", out: "2014-04-26 04:57:07.2 +0000 UTC"},
+		{in: "@500", out: "2014-04-26 11:00:00 +0000 UTC"},
+		{in: "@999", out: "2014-04-26 22:58:33.6 +0000 UTC"},
+		{in: "@1000", err: true},
+		{in: "@99", err: true},
+		{in: "248", err: true},
+		{in: "@-12", err: true},
+		{in: "@24a", err: true},
+		{in: "", err: true},
+	} {
+		ts, err := ParseBeats(th.in, base)
 		if th.err {
-			assert.NotEqual(t, nil, err)
+			assert.NotEqual(t, nil, err, "for in=%v", th.in)
+			continue
+		}
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts), "for in=%v", th.in)
+	}
+
+	// the time is in the location of the base date
+	denver, _ := time.LoadLocation("America/Denver")
+	ts, err := ParseBeats("@500", time.Date(2014, 4, 26, 0, 0, 0, 0, denver))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 05:00:00 -0600 MDT", fmt.Sprintf("%v", ts))
+
+	// not a date-string
+	_, err = ParseAny("@500")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestDotTime(t *testing.T) {
+	for _, th := range []struct {
+		in, out, layout string
+	}{
+		{in: "2014-04-26T15.30.00Z", out: "2014-04-26 15:30:00 +0000 UTC", layout: "2006-01-02T15:04:05Z"},
+		{in: "2014-04-26T15.30.00+01:00", out: "2014-04-26 14:30:00 +0000 UTC", layout: "2006-01-02T15:04:05-07:00"},
+		{in: "2014-04-26T15.30.00-0700", out: "2014-04-26 22:30:00 +0000 UTC", layout: "2006-01-02T15:04:05-0700"},
+		{in: "2014-04-26T15.30.00.123Z", out: "2014-04-26 15:30:00.123 +0000 UTC", layout: "2006-01-02T15:04:05.000Z"},
+		{in: "2014-04-26T15.30.00", out: "2014-04-26 15:30:00 +0000 UTC", layout: "2006-01-02T15:04:05"},
+		{in: "2014-04-26T15.30Z", out: "2014-04-26 15:30:00 +0000 UTC", layout: "2006-01-02T15:04Z"},
+		// colons are still read
+		{in: "2014-04-26T15:30:00Z", out: "2014-04-26 15:30:00 +0000 UTC", layout: "2006-01-02T15:04:05Z"},
+	} {
+		ts, err := ParseAny(th.in, WithDotTime(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+		layout, err := ParseFormat(th.in, WithDotTime(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.layout, layout, "for in=%v", th.in)
+	}
+
+	// the dotted dates are unchanged
+	for _, th := range []dateTest{
+		{in: "26.04.2014 15:30", out: "2014-04-26 15:30:00 +0000 UTC"},
+		{in: "2014.04.26", out: "2014-04-26 00:00:00 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, WithDotTime(true), PreferDayFirst(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	_, err := ParseAny("2014-04-26T25.30.00Z", WithDotTime(true))
+	assert.NotEqual(t, nil, err)
+
+	// not by default
+	_, err = ParseAny("2014-04-26T15.30.00Z")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestTrimTrailingPunctuation(t *testing.T) {
+	for _, th := range []dateTest{
+		{in: "2014-04-26.", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "April 26, 2014!", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "April 26, 2014?!", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "04/26/2014;", out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "2014-04-26 17:24:37,", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "2014-04-26 17:24:37.123.", out: "2014-04-26 17:24:37.123 +0000 UTC"},
+		{in: "Sat, 26 Apr 2014 17:24:37 UTC.", out: "2014-04-26 17:24:37 +0000 UTC"},
+		// the abbreviation is kept
+		{in: "Jan. 26, 2014.", out: "2014-01-26 00:00:00 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, WithTrimTrailingPunctuation(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	assert.Equal(t, "26 Jan.", trimTrailingPunctuation("26 Jan."))
+	assert.Equal(t, "Sat.", trimTrailingPunctuation("Sat."))
+	assert.Equal(t, "26 January", trimTrailingPunctuation("26 January."))
+	assert.Equal(t, "", trimTrailingPunctuation("?!"))
+
+	_, err := ParseAny("!", WithTrimTrailingPunctuation(true))
+	assert.NotEqual(t, nil, err)
+
+	// not by default
+	_, err = ParseAny("April 26, 2014!")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestParseHook(t *testing.T) {
+	utc := WithParseHook(func(t time.Time) (time.Time, error) {
+		return t.UTC(), nil
+	})
+	for _, th := range []dateTest{
+		{in: "2014-04-26 17:24:37 -0700", out: "2014-04-27 00:24:37 +0000 UTC"},
+		{in: "Sat, 26 Apr 2014 17:24:37 +0200", out: "2014-04-26 15:24:37 +0000 UTC"},
+		{in: "1398533077", out: "2014-04-26 17:24:37 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, utc)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, ts.String(), "for in=%v", th.in)
+	}
+
+	// every entry point
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	ts, err := ParseIn("2014-04-26 17:24:37", denverLoc, utc)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 23:24:37 +0000 UTC", ts.String())
+	times, errs := ParseSlice([]string{"2014-04-26 17:24:37", "2014-04-27 17:24:37"}, WithLocation(denverLoc), utc)
+	assert.Equal(t, []error{nil, nil}, errs)
+	assert.Equal(t, "2014-04-27 23:24:37 +0000 UTC", times[1].String())
+
+	since2000 := WithParseHook(func(t time.Time) (time.Time, error) {
+		if t.Year() < 2000 {
+			return t, fmt.Errorf("%v is before 2000", t)
+		}
+		return t, nil
+	})
+	ts, err = ParseAny("2014-04-26", since2000)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 00:00:00 +0000 UTC", ts.String())
+	for _, in := range []string{"1999-12-31 23:59:59", "08/21/71", "332151919"} {
+		_, err := ParseAny(in, since2000)
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+
+	// the hooks apply in order with the other options
+	minute := WithParseHook(func(t time.Time) (time.Time, error) {
+		return t.Round(time.Minute), nil
+	})
+	ts, err = ParseAny("2014-04-26 17:24:37.9", WithPrecision(time.Second), minute)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 17:25:00 +0000 UTC", ts.String())
+
+	_, err = ParseAny("2014-04-26", WithParseHook(nil))
+	assert.Equal(t, nil, err)
+}
+
+func TestStrictRFC3339(t *testing.T) {
+	for _, th := range []dateTest{
+		{in: "2014-04-26T17:24:37Z", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "2014-04-26T17:24:37.123456Z", out: "2014-04-26 17:24:37.123456 +0000 UTC"},
+		{in: "2014-04-26T17:24:37-07:00", out: "2014-04-27 00:24:37 +0000 UTC"},
+		{in: "2014-04-26T17:24:37.1+05:30", out: "2014-04-26 11:54:37.1 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, StrictRFC3339(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	for _, in := range []string{
+		"2014-04-26 17:24:37Z",
+		"2014-04-26 17:24:37",
+		"2014-04-26T17:24:37",
+		"2014-04-26T17:24:37-0700",
+		"2014-04-26",
+		"04/26/2014 17:24:37",
+		"Sat, 26 Apr 2014 17:24:37 MST",
+		"1398533077",
+	} {
+		_, err := ParseAny(in, StrictRFC3339(true))
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+
+		// read by default
+		_, err = ParseAny(in)
+		assert.Equal(t, nil, err, "for in=%v", in)
+	}
+
+	// the other options still apply
+	ts, err := ParseAny("2014-04-26T17:24:37.6Z", StrictRFC3339(true), WithPrecision(time.Second))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 17:24:37 +0000 UTC", fmt.Sprintf("%v", ts))
+
+	// the layout is the RFC 3339 one, the fraction parsed with RFC3339Nano
+	for _, th := range []struct {
+		in, layout string
+	}{
+		{in: "2014-04-26T17:24:37Z", layout: time.RFC3339},
+		{in: "2014-04-26T17:24:37-07:00", layout: time.RFC3339},
+		{in: "2014-04-26T17:24:37.123456Z", layout: time.RFC3339Nano},
+		{in: "2014-04-26T17:24:37.1+05:30", layout: time.RFC3339Nano},
+	} {
+		ts, layout, err := ParseAnyWithFormat(th.in, StrictRFC3339(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.layout, layout, "for in=%v", th.in)
+		again, err := time.Parse(layout, th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.True(t, ts.Equal(again), "for in=%v", th.in)
+	}
+}
+
+func TestLocalDesignator(t *testing.T) {
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	for _, th := range []struct {
+		in, out string
+	}{
+		{in: "2014-04-26T17:24:37L", out: "2014-04-26 17:24:37 -0600 MDT"},
+		{in: "2014-04-26T17:24:37.123L", out: "2014-04-26 17:24:37.123 -0600 MDT"},
+		{in: "2014-04-26T17:24L", out: "2014-04-26 17:24:00 -0600 MDT"},
+		{in: "2014-04-26 17:24:37L", out: "2014-04-26 17:24:37 -0600 MDT"},
+	} {
+		ts, err := ParseAny(th.in, WithLocalDesignator(true), WithLocation(denverLoc))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, ts.String(), "for in=%v", th.in)
+
+		ts, err = ParseIn(th.in, denverLoc, WithLocalDesignator(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, ts.String(), "for in=%v", th.in)
+	}
+
+	// UTC without a location, as any other date-string without an offset
+	ts, err := ParseAny("2014-04-26T17:24:37L", WithLocalDesignator(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 17:24:37 +0000 UTC", ts.String())
+
+	_, err = ParseAny("2014-04-26T25:24:37L", WithLocalDesignator(true))
+	assert.NotEqual(t, nil, err)
+
+	// not by default
+	_, err = ParseAny("2014-04-26T17:24:37L")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestSpacePadding(t *testing.T) {
+	for _, th := range []dateTest{
+		{in: "2014/ 4/ 8", out: "2014-04-08 00:00:00 +0000 UTC"},
+		{in: "2014- 4- 8", out: "2014-04-08 00:00:00 +0000 UTC"},
+		{in: "2014/ 4/ 8 17:24:37", out: "2014-04-08 17:24:37 +0000 UTC"},
+		{in: "2014/12/ 8", out: "2014-12-08 00:00:00 +0000 UTC"},
+		{in: "2014/ 12/ 8", out: "2014-12-08 00:00:00 +0000 UTC"},
+		{in: "2014/ 4/14", out: "2014-04-14 00:00:00 +0000 UTC"},
+		{in: "4/ 8/2014", out: "2014-04-08 00:00:00 +0000 UTC"},
+		{in: "2014-04-26 17:24:37 -0700", out: "2014-04-27 00:24:37 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, WithSpacePadding(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	// a padded field is still in range and padded with one space
+	for _, in := range []string{"2014/ 14/ 8", "2014/  4/ 8", "2014/ 4/  8", "2014-  4- 8"} {
+		_, err := ParseAny(in, WithSpacePadding(true))
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+
+	// the layout parses the padded date-string
+	for _, th := range []struct {
+		in, layout string
+	}{
+		{in: "2014/ 4/ 8", layout: "2006/ 1/_2"},
+		{in: "2014- 4- 8", layout: "2006- 1-_2"},
+		{in: "2014/ 12/ 8", layout: "2006/ 1/_2"},
+		{in: "2014/12/ 8", layout: "2006/01/_2"},
+		{in: "4/ 8/2014", layout: "1/_2/2006"},
+		{in: "2014/ 4/ 8 17:24:37", layout: "2006/ 1/_2 15:04:05"},
+	} {
+		ts, layout, err := ParseAnyWithFormat(th.in, WithSpacePadding(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.layout, layout, "for in=%v", th.in)
+		again, err := time.Parse(layout, th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.True(t, ts.Equal(again), "for in=%v", th.in)
+	}
+
+	// only with the option
+	for _, in := range []string{"2014/ 4/ 8", "2014- 4- 8", "2014/12/ 8", "2014/ 12/ 8", "4/ 8/2014", "2014/ 4/ 8 17:24:37"} {
+		_, err := ParseAny(in)
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+		_, err = ParseFormat(in)
+		assert.NotEqual(t, nil, err, "for in=%v", in)
+	}
+}
+
+func TestGoLayoutTokens(t *testing.T) {
+	for _, th := range []dateTest{
+		{in: "2014-04-26 15:04:05Z0700", out: "2014-04-26 15:04:05 +0000 UTC"},
+		{in: "2014-04-26T15:04:05Z07:00", out: "2014-04-26 15:04:05 +0000 UTC"},
+		{in: "2014-04-26 15:04:05.123Z0700", out: "2014-04-26 15:04:05.123 +0000 UTC"},
+		{in: "2014-04-26 15:04:05Z0700", out: "2014-04-26 15:04:05 +0000 UTC", loc: "America/Denver"},
+	} {
+		var ts time.Time
+		var err error
+		if th.loc != "" {
+			loc, _ := time.LoadLocation(th.loc)
+			ts, err = ParseIn(th.in, loc, WithGoLayoutTokens(true))
 		} else {
-			assert.Equal(t, nil, err)
-			assert.Equal(t, th.out, l, "for in=%v", th.in)
+			ts, err = ParseAny(th.in, WithGoLayoutTokens(true))
 		}
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
 	}
+
+	// only with the option
+	_, err := ParseAny("2014-04-26 15:04:05Z0700")
+	assert.NotEqual(t, nil, err)
 }
 
-var testParseStrict = []dateTest{
-	//   dd-mon-yy  13-Feb-03
-	{in: "03-03-14"},
-	//   mm.dd.yyyy
-	{in: "3.3.2014"},
-	//   mm.dd.yy
-	{in: "08.09.71"},
-	//  mm/dd/yyyy
-	{in: "3/5/2014"},
-	//  mm/dd/yy
-	{in: "08/08/71"},
-	{in: "8/8/71"},
-	//  mm/dd/yy hh:mm:ss
-	{in: "04/02/2014 04:08:09"},
-	{in: "4/2/2014 04:08:09"},
+func TestPlusAsDateTimeSeparator(t *testing.T) {
+	for _, th := range []dateTest{
+		{in: "2014-04-26+17:24:37", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "2014-04-26+17:24:37.123", out: "2014-04-26 17:24:37.123 +0000 UTC"},
+		{in: "2014-04-26+17:24:37Z", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "2014-04-26+17:24:37+05:00", out: "2014-04-26 12:24:37 +0000 UTC"},
+		// the offsets are unchanged
+		{in: "2014-04-26T17:24:37+05:00", out: "2014-04-26 12:24:37 +0000 UTC"},
+		{in: "2014-04-26 17:24:37 +0500", out: "2014-04-26 12:24:37 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, WithPlusAsDateTimeSeparator(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	// in the parse location, the + is not an offset
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	ts, err := ParseIn("2014-04-26+17:24:37", denverLoc, WithPlusAsDateTimeSeparator(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 17:24:37 -0600 MDT", ts.String())
+
+	// an hour and minute is an offset, not a time
+	_, err = ParseAny("2014-04-26+05:00", WithPlusAsDateTimeSeparator(true))
+	assert.NotEqual(t, nil, err)
+	_, err = ParseAny("2014-04-26+25:24:37", WithPlusAsDateTimeSeparator(true))
+	assert.NotEqual(t, nil, err)
+
+	// not by default
+	_, err = ParseAny("2014-04-26+17:24:37")
+	assert.NotEqual(t, nil, err)
 }
 
-func TestParseStrict(t *testing.T) {
+func TestLenientSeconds(t *testing.T) {
+	for _, th := range []dateTest{
+		{in: "2014-04-26 :24:37", out: "2014-04-26 00:24:37 +0000 UTC"},
+		{in: "2014-04-26T:24:37Z", out: "2014-04-26 00:24:37 +0000 UTC"},
+		{in: "2014-04-26 :24:37.123 -0700", out: "2014-04-26 07:24:37.123 +0000 UTC"},
+		{in: "04/26/2014 :24:37", out: "2014-04-26 00:24:37 +0000 UTC"},
+		// the hour is kept when given
+		{in: "2014-04-26 17:24:37", out: "2014-04-26 17:24:37 +0000 UTC"},
+		{in: "2014-04-26 :24", out: "2014-04-26 00:24:00 +0000 UTC"},
+		{in: "2014-04-26 :60:37", err: true},
+		{in: "2014-04-26 ::37", err: true},
+	} {
+		ts, err := ParseAny(th.in, WithLenientSeconds(true))
+		if th.err {
+			assert.NotEqual(t, nil, err, "for in=%v", th.in)
+			continue
+		}
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
 
-	for _, th := range testParseStrict {
-		_, err := ParseStrict(th.in)
-		assert.NotEqual(t, nil, err)
+	// the layout only has the hour when enabled
+	layout, err := ParseFormat("2014-04-26 :24:37", WithLenientSeconds(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2006-01-02 15:04:05", layout)
+	for _, th := range []struct {
+		in, out, layout string
+	}{
+		{in: "2014-04-26 :24:37", out: "2014-04-26 00:24:37 +0000 UTC", layout: "2006-01-02 :04:05"},
+		{in: "2014-04-26 :24", out: "2014-04-26 00:24:00 +0000 UTC", layout: "2006-01-02 :04"},
+		{in: "04/26/2014 :24:37", out: "2014-04-26 00:24:37 +0000 UTC", layout: "01/02/2006 :04:05"},
+	} {
+		ts, layout, err := ParseAnyWithFormat(th.in)
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+		assert.Equal(t, th.layout, layout, "for in=%v", th.in)
 	}
+}
 
-	_, err := ParseStrict(`{"hello"}`)
+func TestMaxFractionDigits(t *testing.T) {
+	for _, th := range []struct {
+		in  string
+		max int
+		err bool
+	}{
+		{in: "2009-08-12T22:15:09.123456789Z", max: 6, err: true},
+		{in: "2009-08-12T22:15:09.1234567Z", max: 6, err: true},
+		{in: "2009-08-12T22:15:09.123456Z", max: 6},
+		{in: "2009-08-12T22:15:09.123Z", max: 6},
+		{in: "2009-08-12T22:15:09Z", max: 6},
+		{in: "2009-08-12 22:15:09.1234 -0700", max: 3, err: true},
+		{in: "2009-08-12 22:15:09,1234", max: 3, err: true},
+		{in: "2009-08-12T22:15:09.1Z", max: 0, err: true},
+		{in: "2009-08-12T22:15:09Z", max: 0},
+	} {
+		_, err := ParseAny(th.in, WithMaxFractionDigits(th.max))
+		if th.err {
+			assert.NotEqual(t, nil, err, "for in=%v", th.in)
+		} else {
+			assert.Equal(t, nil, err, "for in=%v", th.in)
+		}
+	}
+
+	// no cap by default
+	ts, err := ParseAny("2009-08-12T22:15:09.123456789Z")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 123456789, ts.Nanosecond())
+
+	_, err = ParseAny("2009-08-12T22:15:09Z", WithMaxFractionDigits(-1))
 	assert.NotEqual(t, nil, err)
+}
 
-	_, err = ParseStrict("2009-08-12T22:15Z")
+func TestFlexibleEpoch(t *testing.T) {
+	for _, th := range []dateTest{
+		{in: "1,384,216,367", out: "2013-11-12 00:32:47 +0000 UTC"},
+		{in: "1,384,216,367,111", out: "2013-11-12 00:32:47.111 +0000 UTC"},
+		{in: "1,332,151,919", out: "2012-03-19 10:11:59 +0000 UTC"},
+		// ungrouped is unchanged
+		{in: "1384216367", out: "2013-11-12 00:32:47 +0000 UTC"},
+		// not in groups of 3
+		{in: "1,38,4216,367", err: true},
+		{in: "13842,16,367", err: true},
+		{in: "1,384,216,36", err: true},
+	} {
+		ts, err := ParseAny(th.in, WithFlexibleEpoch(true))
+		if th.err {
+			assert.NotEqual(t, nil, err, "for in=%v", th.in)
+			continue
+		}
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+	}
+
+	// by default the error explains the separators
+	_, err := ParseAny("1,384,216,367")
+	assert.NotEqual(t, nil, err)
+	if err != nil {
+		assert.Contains(t, err.Error(), "Thousands separators")
+	}
+	_, err = ParseAny("1,38,4216,367")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestDateOnly(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+
+	// midnight in the zone of the offset, not the next day in UTC
+	ts, err := ParseAny("2014-04-26T23:59:59-07:00", WithDateOnly(true))
+	assert.Equal(t, nil, err)
+	_, offset := ts.Zone()
+	assert.Equal(t, -7*60*60, offset)
+	assert.Equal(t, "2014-04-26 00:00:00", ts.Format("2006-01-02 15:04:05"))
+
+	for _, th := range []struct {
+		in  string
+		loc *time.Location
+		out string
+	}{
+		{in: "2014-04-26 23:59:59", loc: denver, out: "2014-04-26 00:00:00 -0600 MDT"},
+		{in: "2014-04-26 17:24:37.3186369", loc: denver, out: "2014-04-26 00:00:00 -0600 MDT"},
+		{in: "04/26/2014 11:59 PM", loc: denver, out: "2014-04-26 00:00:00 -0600 MDT"},
+		{in: "2014-04-26 23:59:59", loc: time.UTC, out: "2014-04-26 00:00:00 +0000 UTC"},
+		{in: "2014-04-26", loc: denver, out: "2014-04-26 00:00:00 -0600 MDT"},
+		{in: "1332151919", loc: time.UTC, out: "2012-03-19 00:00:00 +0000 UTC"},
+	} {
+		ts, err := ParseAny(th.in, WithLocation(th.loc), WithDateOnly(true))
+		assert.Equal(t, nil, err, "for in=%v", th.in)
+		assert.Equal(t, th.out, ts.String(), "for in=%v", th.in)
+	}
+
+	// the time is kept by default
+	ts, err = ParseIn("2014-04-26 23:59:59", denver)
 	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26 23:59:59 -0600 MDT", ts.String())
+}
+
+var testParseISO = []dateTest{
+	// calendar dates
+	{in: "2014-04-26", out: "2014-04-26 00:00:00 +0000 UTC"},
+	{in: "20140426", out: "2014-04-26 00:00:00 +0000 UTC"},
+	{in: "2014-04", out: "2014-04-01 00:00:00 +0000 UTC"},
+	{in: "2014", out: "2014-01-01 00:00:00 +0000 UTC"},
+	{in: "2016-02-29", out: "2016-02-29 00:00:00 +0000 UTC"},
+	// week dates
+	{in: "2014-W17-6", out: "2014-04-26 00:00:00 +0000 UTC"},
+	{in: "2014W176", out: "2014-04-26 00:00:00 +0000 UTC"},
+	{in: "2014-W17", out: "2014-04-21 00:00:00 +0000 UTC"},
+	{in: "2009-W01-1", out: "2008-12-29 00:00:00 +0000 UTC"},
+	{in: "2009-W53-7", out: "2010-01-03 00:00:00 +0000 UTC"},
+	// ordinal dates
+	{in: "2014-116", out: "2014-04-26 00:00:00 +0000 UTC"},
+	{in: "2014116", out: "2014-04-26 00:00:00 +0000 UTC"},
+	{in: "2016-366", out: "2016-12-31 00:00:00 +0000 UTC"},
+	// times
+	{in: "2014-04-26T17", out: "2014-04-26 17:00:00 +0000 UTC"},
+	{in: "2014-04-26T17:24", out: "2014-04-26 17:24:00 +0000 UTC"},
+	{in: "2014-04-26T17:24:37", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "2014-04-26T17:24:37.1", out: "2014-04-26 17:24:37.1 +0000 UTC"},
+	{in: "2014-04-26T17:24:37,123", out: "2014-04-26 17:24:37.123 +0000 UTC"},
+	{in: "2014-04-26T17:24:37.123456789Z", out: "2014-04-26 17:24:37.123456789 +0000 UTC"},
+	{in: "2014-04-26T24:00:00", out: "2014-04-27 00:00:00 +0000 UTC"},
+	{in: "20140426T172437Z", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "20140426T1724", out: "2014-04-26 17:24:00 +0000 UTC"},
+	{in: "20140426T172437,5+0800", out: "2014-04-26 09:24:37.5 +0000 UTC"},
+	{in: "2014-04-26T17:24.5", out: "2014-04-26 17:24:30 +0000 UTC"},
+	{in: "2014-04-26T17:24,5Z", out: "2014-04-26 17:24:30 +0000 UTC"},
+	{in: "2014-04-26T17:24.0", out: "2014-04-26 17:24:00 +0000 UTC"},
+	{in: "2014-04-26T17:24,123456789", out: "2014-04-26 17:24:07.40740734 +0000 UTC"},
+	{in: "2014-04-26T17.5+08", out: "2014-04-26 09:30:00 +0000 UTC"},
+	{in: "20140426T1724,25Z", out: "2014-04-26 17:24:15 +0000 UTC"},
+	{in: "2014-04-26T24:00.0", out: "2014-04-27 00:00:00 +0000 UTC"},
+	{in: "2014-W17-6T17:24:37Z", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "2014-116T17:24:37Z", out: "2014-04-26 17:24:37 +0000 UTC"},
+	// offsets
+	{in: "2014-04-26T17:24:37Z", out: "2014-04-26 17:24:37 +0000 UTC"},
+	{in: "2014-04-26T17:24:37+08", out: "2014-04-26 09:24:37 +0000 UTC"},
+	{in: "2014-04-26T17:24:37-0530", out: "2014-04-26 22:54:37 +0000 UTC"},
+	{in: "2014-04-26T17:24:37+05:30", out: "2014-04-26 11:54:37 +0000 UTC"},
+	// invalid
+	{in: "", err: true},
+	{in: "14-04-26", err: true},
+	{in: "2014-4-26", err: true},
+	{in: "2014-0426", err: true},
+	{in: "201404", err: true},
+	{in: "2014-13-01", err: true},
+	{in: "2014-02-29", err: true},
+	{in: "2014-000", err: true},
+	{in: "2014-366", err: true},
+	{in: "2014-W00-1", err: true},
+	{in: "2014-W53-1", err: true},
+	{in: "2014-W17-8", err: true},
+	{in: "2014-W176", err: true},
+	{in: "2014-04T17:24", err: true},
+	{in: "2014T17", err: true},
+	{in: "2014-04-26 17:24:37", err: true},
+	{in: "2014-04-26t17:24:37", err: true},
+	{in: "2014-04-26T", err: true},
+	{in: "2014-04-26T1724", err: true},
+	{in: "20140426T17:24", err: true},
+	{in: "2014-04-26T17:24:37.", err: true},
+	{in: "2014-04-26T17:24:37.1234567890", err: true},
+	{in: "2014-04-26T17:24.", err: true},
+	{in: "2014-04-26T24:00.5", err: true},
+	{in: "2014-04-26T24.5", err: true},
+	{in: "2014-04-26T25:00", err: true},
+	{in: "2014-04-26T24:00:01", err: true},
+	{in: "2014-04-26T17:60", err: true},
+	{in: "2014-04-26T17:24:60", err: true},
+	{in: "2014-04-26T17:24:37+8", err: true},
+	{in: "2014-04-26T17:24:37+08:0", err: true},
+	{in: "2014-04-26T17:24:37+24:00", err: true},
+	{in: "2014-04-26T17:24:37 UTC", err: true},
+	{in: "2014-04-26T17:24:37Z+08", err: true},
+}
+
+func TestParseISO(t *testing.T) {
+	for _, th := range testParseISO {
+		ts, err := ParseISO(th.in)
+		if th.err {
+			assert.NotEqual(t, nil, err, "for in=%v", th.in)
+		} else {
+			assert.Equal(t, nil, err, "for in=%v", th.in)
+			assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "for in=%v", th.in)
+		}
+	}
 }
 
 // Lets test to see how this performs using different Timezones/Locations
@@ -669,3 +3855,92 @@ func TestInLocation(t *testing.T) {
 	assert.Equal(t, zeroTime, ts.Unix())
 	assert.NotEqual(t, nil, err)
 }
+
+func TestZoneNameCase(t *testing.T) {
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+
+	// a lower case zone name is read as the upper case one
+	for _, in := range []string{
+		"2012-08-03 13:31:59 MST",
+		"2014-04-26 05:24:37 PST",
+		"Sat, 26 Apr 2014 17:24:37 EST",
+		"Sat, 26 Apr 2014 17:24:37 GMT",
+		"2014-04-26 17:24:37 -0700 PDT",
+		"2014-04-26 17:24:37.123 UTC",
+		"Mon Jan  2 15:04:05 MST 2006",
+	} {
+		upper, err := ParseIn(in, denverLoc)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		lower, err := ParseIn(strings.ToLower(in), denverLoc)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		assert.Equal(t, upper.String(), lower.String(), "for in=%v", in)
+		layout, err := ParseFormat(in)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		lowerLayout, err := ParseFormat(strings.ToLower(in))
+		assert.Equal(t, nil, err, "for in=%v", in)
+		assert.Equal(t, layout, lowerLayout, "for in=%v", in)
+	}
+	ts, err := ParseIn("2012-08-03 13:31:59 mst", denverLoc)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2012-08-03 20:31:59 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+}
+
+func TestAssumeLocalIfZoneless(t *testing.T) {
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	defer func() { time.Local = time.UTC }()
+
+	time.Local = denverLoc
+	ts, err := ParseAny("2013-02-01 00:00:00", AssumeLocalIfZoneless(true))
+	assert.Equal(t, nil, err)
+	zone, offset := ts.Zone()
+	assert.Equal(t, -25200, offset, "Should have found offset = -25200 %v  %v", offset, denverLoc)
+	assert.Equal(t, "MST", zone, "Should have found zone = MST %v", zone)
+	assert.Equal(t, "2013-02-01 07:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	ts, err = ParseAny("2013-04-01 00:00:00", AssumeLocalIfZoneless(true))
+	assert.Equal(t, nil, err)
+	zone, offset = ts.Zone()
+	assert.Equal(t, -21600, offset, "Should have found offset = -21600 %v  %v", offset, denverLoc)
+	assert.Equal(t, "MDT", zone, "Should have found zone = MDT %v", zone)
+	assert.Equal(t, "2013-04-01 06:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// the same as ParseLocal
+	for _, in := range []string{
+		"2013-02-01 00:00:00",
+		"18 January 2018",
+		"3/1/2014",
+		"Mon Jan  2 15:04:05 MST 2006",
+		"Tue, 5 Jul 2017 16:28:13 -0700 (MST)",
+		"2013-02-01T00:00:00Z",
+	} {
+		want, err := ParseLocal(in)
+		assert.Equal(t, nil, err, "for in=%v", in)
+		ts, err := ParseAny(in, AssumeLocalIfZoneless(true))
+		assert.Equal(t, nil, err, "for in=%v", in)
+		assert.Equal(t, want.String(), ts.String(), "for in=%v", in)
+	}
+
+	// offsets are honored as-is
+	ts, err = ParseAny("Tue, 5 Jul 2017 16:28:13 -0700 (MST)", AssumeLocalIfZoneless(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2017-07-05 23:28:13 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+	ts, err = ParseAny("2013-02-01T00:00:00Z", AssumeLocalIfZoneless(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2013-02-01 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// without the option the zoneless date-string is UTC
+	ts, err = ParseAny("2013-02-01 00:00:00", AssumeLocalIfZoneless(false))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2013-02-01 00:00:00 +0000 UTC", fmt.Sprintf("%v", ts))
+
+	// WithLocation is used instead of time.Local
+	time.Local = time.UTC
+	ts, err = ParseAny("2013-02-01 00:00:00", WithLocation(denverLoc), AssumeLocalIfZoneless(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2013-02-01 07:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+	ts, err = ParseAny("2013-02-01 00:00:00", AssumeLocalIfZoneless(true), WithLocation(denverLoc))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2013-02-01 07:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+}